@@ -44,6 +44,8 @@ do
           "github.com/cloudevents/sdk-go/protocol/nats/v2"
           "github.com/cloudevents/sdk-go/protocol/pubsub/v2"
           "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+          "github.com/cloudevents/sdk-go/observability/opentelemetry/v2"
+          "github.com/cloudevents/sdk-go/observability/prometheus/v2"
           "github.com/cloudevents/sdk-go/v2"                       # NOTE: this needs to be last.
         )
         shift
@@ -104,6 +106,8 @@ MODULES=(
   "protocol/nats"
   "protocol/pubsub"
   "protocol/kafka_sarama"
+  "observability/opentelemetry"
+  "observability/prometheus"
 )
 
 for i in "${MODULES[@]}"; do
@@ -0,0 +1,26 @@
+/*
+Package opentelemetry provides an OpenTelemetry implementation of the
+client.ObservabilityService of the CloudEvents client.
+
+The service creates spans following the OpenTelemetry messaging semantic
+conventions for the sent, requested and received events, and records metrics
+counting the sent, received, acknowledged and not acknowledged events, with
+the duration of the operations and the size of the event data.
+
+	svc, err := opentelemetry.NewObservabilityService(opentelemetry.WithMessagingSystem("kafka"))
+	...
+	c, err := client.New(p, client.WithObservabilityService(svc), client.WithTracePropagation())
+
+The trace context is propagated in the native headers of the protocols
+supporting them, and in the distributed tracing extension of the events when
+the client is created with client.WithTracePropagation.
+
+The protocols can be instrumented too, also when they are used without client,
+e.g. in a bridge, with ObservabilityService.WrapSender and
+ObservabilityService.WrapReceiver: they create a span for each sent and
+received message and record the cloudevents.protocol metrics, mirroring the
+cloudevents.client ones. The protocol modules don't depend on OpenTelemetry:
+the messages are instrumented at the protocol.Sender and protocol.Receiver
+boundary, not inside each protocol.
+*/
+package opentelemetry
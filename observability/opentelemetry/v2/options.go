@@ -0,0 +1,69 @@
+package opentelemetry
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option is the function signature required to be considered an opentelemetry.Option.
+type Option func(*ObservabilityService) error
+
+// WithTracerProvider sets the TracerProvider creating the spans.
+// Default is the global TracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(s *ObservabilityService) error {
+		if provider == nil {
+			return fmt.Errorf("opentelemetry option was given a nil tracer provider")
+		}
+		s.tracerProvider = provider
+		return nil
+	}
+}
+
+// WithMeterProvider sets the MeterProvider recording the metrics.
+// Default is the global MeterProvider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(s *ObservabilityService) error {
+		if provider == nil {
+			return fmt.Errorf("opentelemetry option was given a nil meter provider")
+		}
+		s.meterProvider = provider
+		return nil
+	}
+}
+
+// WithPropagator sets the propagator reading and writing the trace context of
+// the native protocol headers and of the distributed tracing extension.
+// Default is the W3C trace context propagator, the only one supported by the
+// distributed tracing extension.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(s *ObservabilityService) error {
+		if propagator == nil {
+			return fmt.Errorf("opentelemetry option was given a nil propagator")
+		}
+		s.propagator = propagator
+		return nil
+	}
+}
+
+// WithMessagingSystem sets the messaging.system attribute of the spans and
+// metrics, e.g. "kafka" or "rabbitmq". Default is "cloudevents".
+func WithMessagingSystem(system string) Option {
+	return func(s *ObservabilityService) error {
+		s.system = system
+		return nil
+	}
+}
+
+// WithDestination sets the messaging.destination.name attribute of the spans
+// and metrics. By default the destination is read from the topic or the target
+// of the context, when set.
+func WithDestination(destination string) Option {
+	return func(s *ObservabilityService) error {
+		s.destination = destination
+		return nil
+	}
+}
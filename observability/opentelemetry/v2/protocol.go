@@ -0,0 +1,122 @@
+package opentelemetry
+
+import (
+	"context"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+// WrapSender returns sender instrumented with a producer span for each sent
+// message, propagated by the protocol in the native headers, and with the
+// cloudevents.protocol metrics. Use it to instrument any protocol, also when
+// it's used without client, e.g. in a bridge.
+func (s *ObservabilityService) WrapSender(sender protocol.Sender) protocol.Sender {
+	return &sendWrapper{Sender: sender, service: s}
+}
+
+// WrapReceiver returns receiver instrumented with a consumer span for each
+// received message, parented to the trace context of the native headers and
+// ended when the message is finished, and with the cloudevents.protocol
+// metrics. The returned Receiver is a protocol.Opener if receiver is one.
+func (s *ObservabilityService) WrapReceiver(receiver protocol.Receiver) protocol.Receiver {
+	r := &receiveWrapper{Receiver: receiver, service: s}
+	if opener, ok := receiver.(protocol.Opener); ok {
+		return &openerReceiveWrapper{receiveWrapper: r, opener: opener}
+	}
+	return r
+}
+
+type sendWrapper struct {
+	protocol.Sender
+	service *ObservabilityService
+}
+
+func (w *sendWrapper) Send(ctx context.Context, m binding.Message, transformers ...binding.Transformer) error {
+	e, size := messageEvent(m)
+	ctx, end := w.service.record(ctx, w.service.protocol, semconv.MessagingOperationPublish, e, size, trace.WithSpanKind(trace.SpanKindProducer))
+	err := w.Sender.Send(ctx, m, transformers...)
+	end(err)
+	return err
+}
+
+func (w *sendWrapper) Close(ctx context.Context) error {
+	if closer, ok := w.Sender.(protocol.Closer); ok {
+		return closer.Close(ctx)
+	}
+	return nil
+}
+
+type receiveWrapper struct {
+	protocol.Receiver
+	service *ObservabilityService
+}
+
+func (w *receiveWrapper) Receive(ctx context.Context) (binding.Message, error) {
+	m, err := w.Receiver.Receive(ctx)
+	if err != nil {
+		return m, err
+	}
+	e, size := messageEvent(m)
+	ctx = w.service.extractNativeTraceContext(ctx, m)
+	_, end := w.service.record(ctx, w.service.protocol, semconv.MessagingOperationReceive, e, size, trace.WithSpanKind(trace.SpanKindConsumer))
+	return binding.WithFinish(m, end), nil
+}
+
+func (w *receiveWrapper) Close(ctx context.Context) error {
+	if closer, ok := w.Receiver.(protocol.Closer); ok {
+		return closer.Close(ctx)
+	}
+	return nil
+}
+
+type openerReceiveWrapper struct {
+	*receiveWrapper
+	opener protocol.Opener
+}
+
+func (w *openerReceiveWrapper) OpenInbound(ctx context.Context) error {
+	return w.opener.OpenInbound(ctx)
+}
+
+// messageEvent returns the event holding the attributes of m, and the size of
+// its data, without consuming m: the attributes of structured messages and the
+// data of binary messages are not read.
+func messageEvent(m binding.Message) (*event.Event, int) {
+	switch m.ReadEncoding() {
+	case binding.EncodingEvent:
+		if e, err := binding.ToEvent(context.Background(), m); err == nil {
+			return e, len(e.Data())
+		}
+	case binding.EncodingBinary:
+		if r, ok := binding.UnwrapMessage(m).(binding.MessageMetadataReader); ok {
+			e := event.New()
+			for kind, set := range map[spec.Kind]func(string){
+				spec.ID:      e.SetID,
+				spec.Type:    e.SetType,
+				spec.Source:  e.SetSource,
+				spec.Subject: e.SetSubject,
+			} {
+				if _, v := r.GetAttribute(kind); v != nil {
+					if str, err := types.Format(v); err == nil {
+						set(str)
+					}
+				}
+			}
+			return &e, -1
+		}
+	}
+	return nil, -1
+}
+
+var _ protocol.Sender = (*sendWrapper)(nil)
+var _ protocol.Closer = (*sendWrapper)(nil)
+var _ protocol.Receiver = (*receiveWrapper)(nil)
+var _ protocol.Closer = (*receiveWrapper)(nil)
+var _ protocol.Opener = (*openerReceiveWrapper)(nil)
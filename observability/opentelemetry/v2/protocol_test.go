@@ -0,0 +1,54 @@
+package opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/cloudevents/sdk-go/v2/protocol/gochan"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+)
+
+func TestWrapSenderReceiver(t *testing.T) {
+	svc, spans, reader := newTestService(t)
+	p := gochan.New()
+	sender, receiver := svc.WrapSender(p), svc.WrapReceiver(p)
+
+	e := newTestEvent()
+	require.NoError(t, sender.Send(context.Background(), binding.ToMessage(&e)))
+	m, err := receiver.Receive(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, m.Finish(protocol.ResultNACK))
+
+	require.Len(t, spans.Ended(), 2)
+	producerSpan, consumerSpan := spans.Ended()[0], spans.Ended()[1]
+	require.Equal(t, "events publish", producerSpan.Name())
+	require.Equal(t, trace.SpanKindProducer, producerSpan.SpanKind())
+	require.Contains(t, producerSpan.Attributes(), semconv.MessagingMessageID("1"))
+	require.Contains(t, producerSpan.Attributes(), semconv.MessagingMessageBodySize(5))
+	require.Equal(t, "events receive", consumerSpan.Name())
+	require.Equal(t, trace.SpanKindConsumer, consumerSpan.SpanKind())
+	require.Equal(t, "Error", consumerSpan.Status().Code.String())
+
+	require.Equal(t, int64(1), counterValue(t, reader, "cloudevents.protocol.sent"))
+	require.Equal(t, int64(1), counterValue(t, reader, "cloudevents.protocol.received"))
+	require.Equal(t, int64(1), counterValue(t, reader, "cloudevents.protocol.acked"))
+	require.Equal(t, int64(1), counterValue(t, reader, "cloudevents.protocol.nacked"))
+	require.Equal(t, int64(0), counterValue(t, reader, "cloudevents.client.sent"))
+}
+
+func TestWrapReceiver_Opener(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	_, ok := svc.WrapReceiver(gochan.New()).(protocol.Opener)
+	require.False(t, ok)
+
+	p, err := cehttp.New()
+	require.NoError(t, err)
+	_, ok = svc.WrapReceiver(p).(protocol.Opener)
+	require.True(t, ok)
+}
@@ -0,0 +1,298 @@
+package opentelemetry
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/client"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+const (
+	// instrumentationName is the name of the tracer and of the meter.
+	instrumentationName = "github.com/cloudevents/sdk-go/observability/opentelemetry/v2"
+
+	// DefaultMessagingSystem is the default messaging.system attribute.
+	DefaultMessagingSystem = "cloudevents"
+)
+
+// ObservabilityService is a client.ObservabilityService creating OpenTelemetry
+// spans and metrics.
+type ObservabilityService struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+	system         string
+	destination    string
+
+	tracer trace.Tracer
+	// client records the events of the client, protocol the messages of the
+	// wrapped protocols, see WrapSender and WrapReceiver
+	client   *instruments
+	protocol *instruments
+}
+
+// instruments are the metrics of the sent and received events or messages.
+type instruments struct {
+	sent     metric.Int64Counter
+	received metric.Int64Counter
+	acked    metric.Int64Counter
+	nacked   metric.Int64Counter
+	duration metric.Float64Histogram
+	bodySize metric.Int64Histogram
+}
+
+var _ client.ObservabilityService = (*ObservabilityService)(nil)
+
+// NewObservabilityService returns a new ObservabilityService, using the global
+// TracerProvider and MeterProvider unless configured otherwise.
+func NewObservabilityService(opts ...Option) (*ObservabilityService, error) {
+	s := &ObservabilityService{
+		propagator: propagation.TraceContext{},
+		system:     DefaultMessagingSystem,
+	}
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+	if s.tracerProvider == nil {
+		s.tracerProvider = otel.GetTracerProvider()
+	}
+	if s.meterProvider == nil {
+		s.meterProvider = otel.GetMeterProvider()
+	}
+
+	s.tracer = s.tracerProvider.Tracer(instrumentationName)
+	meter := s.meterProvider.Meter(instrumentationName)
+	var err error
+	if s.client, err = newInstruments(meter, "cloudevents.client", "events"); err != nil {
+		return nil, err
+	}
+	if s.protocol, err = newInstruments(meter, "cloudevents.protocol", "messages"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// newInstruments creates the metrics named prefix.sent, prefix.received and so
+// on, counting the things.
+func newInstruments(meter metric.Meter, prefix string, things string) (*instruments, error) {
+	unit := metric.WithUnit("{" + strings.TrimSuffix(things, "s") + "}")
+	i := &instruments{}
+	var err error
+	if i.sent, err = meter.Int64Counter(prefix+".sent",
+		metric.WithDescription("The number of "+things+" sent or requested."), unit); err != nil {
+		return nil, err
+	}
+	if i.received, err = meter.Int64Counter(prefix+".received",
+		metric.WithDescription("The number of "+things+" received."), unit); err != nil {
+		return nil, err
+	}
+	if i.acked, err = meter.Int64Counter(prefix+".acked",
+		metric.WithDescription("The number of "+things+" acknowledged, by the recipient of the sent "+things+" or by the receiver of the received "+things+"."), unit); err != nil {
+		return nil, err
+	}
+	if i.nacked, err = meter.Int64Counter(prefix+".nacked",
+		metric.WithDescription("The number of "+things+" not acknowledged, including the delivery failures and the malformed incoming "+things+"."), unit); err != nil {
+		return nil, err
+	}
+	if i.duration, err = meter.Float64Histogram(prefix+".duration",
+		metric.WithDescription("The duration of the sending, requesting and processing of the "+things+"."), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if i.bodySize, err = meter.Int64Histogram(prefix+".body.size",
+		metric.WithDescription("The size of the data of the "+things+"."), metric.WithUnit("By")); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// InboundContextDecorators implements client.ObservabilityService, extracting
+// the trace context of the native headers of the incoming messages.
+func (s *ObservabilityService) InboundContextDecorators() []func(context.Context, binding.Message) context.Context {
+	return []func(context.Context, binding.Message) context.Context{s.extractNativeTraceContext}
+}
+
+func (s *ObservabilityService) extractNativeTraceContext(ctx context.Context, m binding.Message) context.Context {
+	if tc, ok := extensions.ReadNativeTraceContext(m); ok {
+		return s.propagator.Extract(ctx, carrier(tc))
+	}
+	return ctx
+}
+
+// RecordReceivedMalformedEvent implements client.ObservabilityService.
+func (s *ObservabilityService) RecordReceivedMalformedEvent(ctx context.Context, err error) {
+	attrs := metric.WithAttributes(append(s.metricAttributes(ctx, semconv.MessagingOperationDeliver, nil), semconv.ErrorTypeKey.String("malformed"))...)
+	s.client.received.Add(ctx, 1, attrs)
+	s.client.nacked.Add(ctx, 1, attrs)
+	trace.SpanFromContext(ctx).RecordError(err)
+}
+
+// RecordCallingInvoker implements client.ObservabilityService, starting a
+// consumer span parented to the trace context of the native headers, or
+// otherwise to the distributed tracing extension of e. When the event passed
+// through a bridge, the two differ and the span is linked to the producer
+// span.
+func (s *ObservabilityService) RecordCallingInvoker(ctx context.Context, e *event.Event) (context.Context, func(errOrResult error)) {
+	opts := []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindConsumer)}
+	if e != nil {
+		if producer := s.producerSpanContext(*e); producer.IsValid() {
+			if parent := trace.SpanContextFromContext(ctx); !parent.IsValid() {
+				ctx = trace.ContextWithRemoteSpanContext(ctx, producer)
+			} else if !parent.Equal(producer) {
+				opts = append(opts, trace.WithLinks(trace.Link{SpanContext: producer}))
+			}
+		}
+	}
+	return s.record(ctx, s.client, semconv.MessagingOperationDeliver, e, bodySize(e), opts...)
+}
+
+// RecordSendingEvent implements client.ObservabilityService, starting a
+// producer span whose trace context is propagated by the protocol.
+func (s *ObservabilityService) RecordSendingEvent(ctx context.Context, e event.Event) (context.Context, func(errOrResult error)) {
+	return s.record(ctx, s.client, semconv.MessagingOperationPublish, &e, len(e.Data()), trace.WithSpanKind(trace.SpanKindProducer))
+}
+
+// RecordRequestEvent implements client.ObservabilityService, starting a
+// client span whose trace context is propagated by the protocol.
+func (s *ObservabilityService) RecordRequestEvent(ctx context.Context, e event.Event) (context.Context, func(errOrResult error, event *event.Event)) {
+	ctx, cb := s.record(ctx, s.client, semconv.MessagingOperationPublish, &e, len(e.Data()), trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, func(errOrResult error, _ *event.Event) {
+		cb(errOrResult)
+	}
+}
+
+// record starts the span of an operation on e, counting e with the instruments
+// i, and returns the function ending the span and recording the result.
+// size is the size of the data of e, negative if unknown.
+func (s *ObservabilityService) record(ctx context.Context, i *instruments, operation attribute.KeyValue, e *event.Event, size int, opts ...trace.SpanStartOption) (context.Context, func(error)) {
+	start := time.Now()
+	attrs := s.metricAttributes(ctx, operation, e)
+	spanAttrs := append(s.spanAttributes(e, size), attrs...)
+	ctx, span := s.tracer.Start(ctx, s.spanName(ctx, operation), append(opts, trace.WithAttributes(spanAttrs...))...)
+
+	inbound := operation == semconv.MessagingOperationDeliver || operation == semconv.MessagingOperationReceive
+	if inbound {
+		i.received.Add(ctx, 1, metric.WithAttributes(attrs...))
+	} else {
+		i.sent.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+	if size >= 0 {
+		i.bodySize.Record(ctx, int64(size), metric.WithAttributes(attrs...))
+	}
+
+	// Let the protocols propagate the span in native headers
+	if !inbound {
+		c := propagation.MapCarrier{}
+		s.propagator.Inject(ctx, c)
+		ctx = extensions.ContextWithTraceContext(ctx, extensions.DistributedTracingExtension{
+			TraceParent: c.Get(extensions.TraceParentHeader),
+			TraceState:  c.Get(extensions.TraceStateHeader),
+		})
+	}
+
+	return ctx, func(errOrResult error) {
+		if protocol.IsACK(errOrResult) {
+			i.acked.Add(ctx, 1, metric.WithAttributes(attrs...))
+		} else {
+			i.nacked.Add(ctx, 1, metric.WithAttributes(attrs...))
+			span.RecordError(errOrResult)
+			span.SetStatus(codes.Error, errOrResult.Error())
+		}
+		i.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+		span.End()
+	}
+}
+
+// producerSpanContext returns the span context of the distributed tracing
+// extension of e.
+func (s *ObservabilityService) producerSpanContext(e event.Event) trace.SpanContext {
+	ext, ok := extensions.GetDistributedTracingExtension(e)
+	if !ok {
+		return trace.SpanContext{}
+	}
+	return trace.SpanContextFromContext(s.propagator.Extract(context.Background(), carrier(ext)))
+}
+
+func (s *ObservabilityService) destinationName(ctx context.Context) string {
+	if s.destination != "" {
+		return s.destination
+	}
+	if topic := cecontext.TopicFrom(ctx); topic != "" {
+		return topic
+	}
+	if target := cecontext.TargetFrom(ctx); target != nil {
+		return target.String()
+	}
+	return ""
+}
+
+// spanName follows the messaging semantic conventions:
+// "{destination} {operation}", or "{operation}" if the destination is unknown.
+func (s *ObservabilityService) spanName(ctx context.Context, operation attribute.KeyValue) string {
+	if destination := s.destinationName(ctx); destination != "" {
+		return destination + " " + operation.Value.AsString()
+	}
+	return operation.Value.AsString()
+}
+
+func (s *ObservabilityService) metricAttributes(ctx context.Context, operation attribute.KeyValue, e *event.Event) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.MessagingSystemKey.String(s.system), operation}
+	if destination := s.destinationName(ctx); destination != "" {
+		attrs = append(attrs, semconv.MessagingDestinationName(destination))
+	}
+	if e != nil {
+		attrs = append(attrs, semconv.CloudeventsEventType(e.Type()))
+	}
+	return attrs
+}
+
+func (s *ObservabilityService) spanAttributes(e *event.Event, size int) []attribute.KeyValue {
+	if e == nil {
+		return nil
+	}
+	attrs := []attribute.KeyValue{
+		semconv.MessagingMessageID(e.ID()),
+		semconv.CloudeventsEventID(e.ID()),
+		semconv.CloudeventsEventSource(e.Source()),
+		semconv.CloudeventsEventSpecVersion(e.SpecVersion()),
+	}
+	if size >= 0 {
+		attrs = append(attrs, semconv.MessagingMessageBodySize(size))
+	}
+	if subject := e.Subject(); subject != "" {
+		attrs = append(attrs, semconv.CloudeventsEventSubject(subject))
+	}
+	return attrs
+}
+
+// bodySize returns the size of the data of e, or -1 if e is nil.
+func bodySize(e *event.Event) int {
+	if e == nil {
+		return -1
+	}
+	return len(e.Data())
+}
+
+// carrier returns the W3C trace context headers of tc.
+func carrier(tc extensions.DistributedTracingExtension) propagation.MapCarrier {
+	c := propagation.MapCarrier{extensions.TraceParentHeader: tc.TraceParent}
+	if tc.TraceState != "" {
+		c[extensions.TraceStateHeader] = tc.TraceState
+	}
+	return c
+}
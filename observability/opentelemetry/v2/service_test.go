@@ -0,0 +1,153 @@
+package opentelemetry
+
+import (
+	"context"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/client"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/cloudevents/sdk-go/v2/protocol/gochan"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+)
+
+func newTestService(t *testing.T) (*ObservabilityService, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	svc, err := NewObservabilityService(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		WithMessagingSystem("gochan"),
+		WithDestination("events"),
+	)
+	require.NoError(t, err)
+	return svc, spans, reader
+}
+
+func newTestEvent() event.Event {
+	e := event.New()
+	e.SetID("1")
+	e.SetType("com.example.test")
+	e.SetSource("/test")
+	_ = e.SetData(event.TextPlain, "hello")
+	return e
+}
+
+func counterValue(t *testing.T, reader *sdkmetric.ManualReader, name string) int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == name {
+				for _, dp := range sum.DataPoints {
+					total += dp.Value
+				}
+			}
+		}
+	}
+	return total
+}
+
+func TestSendReceive(t *testing.T) {
+	svc, spans, reader := newTestService(t)
+	p := gochan.New()
+	c, err := client.New(p, client.WithObservabilityService(svc), client.WithTracePropagation())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan trace.SpanContext, 1)
+	go func() {
+		_ = c.StartReceiver(ctx, func(ctx context.Context, e event.Event) protocol.Result {
+			received <- trace.SpanContextFromContext(ctx)
+			return nil
+		})
+	}()
+
+	require.True(t, protocol.IsACK(c.Send(context.Background(), newTestEvent())))
+	var consumer trace.SpanContext
+	select {
+	case consumer = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the event")
+	}
+	require.Eventually(t, func() bool { return len(spans.Ended()) == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	producerSpan, consumerSpan := spans.Ended()[0], spans.Ended()[1]
+	if producerSpan.SpanKind() != trace.SpanKindProducer {
+		producerSpan, consumerSpan = consumerSpan, producerSpan
+	}
+	require.Equal(t, "events publish", producerSpan.Name())
+	require.Equal(t, "events deliver", consumerSpan.Name())
+	require.Equal(t, trace.SpanKindConsumer, consumerSpan.SpanKind())
+	require.Equal(t, consumer.SpanID(), consumerSpan.SpanContext().SpanID())
+	require.Equal(t, producerSpan.SpanContext().SpanID(), consumerSpan.Parent().SpanID())
+	require.Contains(t, producerSpan.Attributes(), semconv.MessagingMessageID("1"))
+	require.Contains(t, producerSpan.Attributes(), semconv.MessagingMessageBodySize(5))
+	require.Contains(t, producerSpan.Attributes(), attribute.String("messaging.system", "gochan"))
+
+	require.Equal(t, int64(1), counterValue(t, reader, "cloudevents.client.sent"))
+	require.Equal(t, int64(1), counterValue(t, reader, "cloudevents.client.received"))
+	require.Equal(t, int64(2), counterValue(t, reader, "cloudevents.client.acked"))
+	require.Equal(t, int64(0), counterValue(t, reader, "cloudevents.client.nacked"))
+}
+
+func TestRecordCallingInvoker_LinksProducer(t *testing.T) {
+	svc, spans, _ := newTestService(t)
+
+	// The event was produced in a trace, and forwarded by a bridge in another
+	_, producer := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "producer")
+	_, bridge := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "bridge")
+	e := newTestEvent()
+	e.SetExtension("traceparent", traceParent(producer.SpanContext()))
+
+	_, cb := svc.RecordCallingInvoker(trace.ContextWithRemoteSpanContext(context.Background(), bridge.SpanContext()), &e)
+	cb(protocol.NewReceipt(false, "failed"))
+
+	var consumer sdktrace.ReadOnlySpan
+	for _, s := range spans.Ended() {
+		if s.SpanKind() == trace.SpanKindConsumer {
+			consumer = s
+		}
+	}
+	require.NotNil(t, consumer)
+	require.Equal(t, bridge.SpanContext().SpanID(), consumer.Parent().SpanID())
+	require.Len(t, consumer.Links(), 1)
+	require.Equal(t, producer.SpanContext().SpanID(), consumer.Links()[0].SpanContext.SpanID())
+	require.Equal(t, "Error", consumer.Status().Code.String())
+}
+
+func traceParent(sc trace.SpanContext) string {
+	return "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01"
+}
+
+func TestInboundContextDecorators_WrappedMessage(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	_, hop := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "hop")
+
+	header := nethttp.Header{}
+	header.Set(extensions.TraceParentHeader, traceParent(hop.SpanContext()))
+	// Like the messages of the Kafka receiver
+	m := binding.WithFinish(cehttp.NewMessage(header, nil), nil)
+
+	ctx := context.Background()
+	for _, decorator := range svc.InboundContextDecorators() {
+		ctx = decorator(ctx, m)
+	}
+	require.Equal(t, hop.SpanContext().TraceID(), trace.SpanContextFromContext(ctx).TraceID())
+	require.Equal(t, hop.SpanContext().SpanID(), trace.SpanContextFromContext(ctx).SpanID())
+}
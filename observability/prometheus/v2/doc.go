@@ -0,0 +1,22 @@
+/*
+Package prometheus provides Prometheus collectors for the activity of the
+CloudEvents clients and protocols.
+
+Metrics implements client.ObservabilityService, so the client records the
+events it sends and receives without changes to the handlers:
+
+	metrics := prometheus.New(prometheus.WithProtocol("kafka"))
+	registry.MustRegister(metrics)
+	c, err := client.New(p, client.WithObservabilityService(metrics))
+
+Use client.MultiObservabilityService to combine it with other services, e.g.
+the OpenTelemetry one.
+
+The protocols used without a client can be wrapped with Metrics.WrapSender
+and Metrics.WrapReceiver instead. The wrappers are also a protocol.Opener, a
+protocol.Closer or a protocol.Responder when the wrapped protocol is. Don't combine both on the same events, as
+they would be counted twice.
+
+All the metrics are labelled by protocol, event type and encoding.
+*/
+package prometheus
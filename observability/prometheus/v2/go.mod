@@ -0,0 +1,11 @@
+module github.com/cloudevents/sdk-go/observability/prometheus/v2
+
+go 1.14
+
+replace github.com/cloudevents/sdk-go/v2 => ../../../v2
+
+require (
+	github.com/cloudevents/sdk-go/v2 v2.0.0
+	github.com/prometheus/client_golang v1.7.1
+	github.com/stretchr/testify v1.5.1
+)
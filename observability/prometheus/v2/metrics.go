@@ -0,0 +1,245 @@
+package prometheus
+
+import (
+	"context"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/client"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+const (
+	// DefaultNamespace is the default namespace of the metric names.
+	DefaultNamespace = "cloudevents"
+
+	directionOutbound = "outbound"
+	directionInbound  = "inbound"
+
+	resultACK         = "ack"
+	resultNACK        = "nack"
+	resultUndelivered = "undelivered"
+)
+
+var (
+	// DefaultDurationBuckets are the default buckets, in seconds, of the handler duration histogram.
+	DefaultDurationBuckets = prom.DefBuckets
+	// DefaultSizeBuckets are the default buckets, in bytes, of the event data size histogram.
+	DefaultSizeBuckets = prom.ExponentialBuckets(64, 4, 8)
+
+	labels = []string{"protocol", "type", "encoding"}
+)
+
+// Metrics holds the Prometheus collectors of the CloudEvents activity.
+// Register it in a prometheus.Registerer, then plug it in the client with
+// client.WithObservabilityService, or wrap the protocols with WrapSender and
+// WrapReceiver.
+type Metrics struct {
+	namespace       string
+	protocol        string
+	durationBuckets []float64
+	sizeBuckets     []float64
+
+	sent     *prom.CounterVec
+	received *prom.CounterVec
+	results  *prom.CounterVec
+	retries  *prom.CounterVec
+	duration *prom.HistogramVec
+	size     *prom.HistogramVec
+	inFlight *prom.GaugeVec
+}
+
+var (
+	_ prom.Collector              = (*Metrics)(nil)
+	_ client.ObservabilityService = (*Metrics)(nil)
+)
+
+// New returns new Metrics.
+func New(opts ...Option) *Metrics {
+	m := &Metrics{
+		namespace:       DefaultNamespace,
+		durationBuckets: DefaultDurationBuckets,
+		sizeBuckets:     DefaultSizeBuckets,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	m.sent = prom.NewCounterVec(prom.CounterOpts{
+		Namespace: m.namespace,
+		Name:      "events_sent_total",
+		Help:      "The number of events sent or requested.",
+	}, labels)
+	m.received = prom.NewCounterVec(prom.CounterOpts{
+		Namespace: m.namespace,
+		Name:      "events_received_total",
+		Help:      "The number of events received, including the malformed ones.",
+	}, labels)
+	m.results = prom.NewCounterVec(prom.CounterOpts{
+		Namespace: m.namespace,
+		Name:      "event_results_total",
+		Help:      "The number of events acknowledged (ack), not acknowledged (nack) or not delivered (undelivered), by the recipient of the outbound events or by the handler of the inbound events.",
+	}, append(labels, "direction", "result"))
+	m.retries = prom.NewCounterVec(prom.CounterOpts{
+		Namespace: m.namespace,
+		Name:      "send_retries_total",
+		Help:      "The number of failed attempts retried while sending events.",
+	}, labels)
+	m.duration = prom.NewHistogramVec(prom.HistogramOpts{
+		Namespace: m.namespace,
+		Name:      "handler_duration_seconds",
+		Help:      "The duration of the handling of the inbound events.",
+		Buckets:   m.durationBuckets,
+	}, labels)
+	m.size = prom.NewHistogramVec(prom.HistogramOpts{
+		Namespace: m.namespace,
+		Name:      "event_data_size_bytes",
+		Help:      "The size of the data of the events.",
+		Buckets:   m.sizeBuckets,
+	}, append(labels, "direction"))
+	m.inFlight = prom.NewGaugeVec(prom.GaugeOpts{
+		Namespace: m.namespace,
+		Name:      "handlers_in_flight",
+		Help:      "The number of inbound events being handled.",
+	}, labels)
+	return m
+}
+
+func (m *Metrics) collectors() []prom.Collector {
+	return []prom.Collector{m.sent, m.received, m.results, m.retries, m.duration, m.size, m.inFlight}
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prom.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prom.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+type encodingKey struct{}
+
+// InboundContextDecorators implements client.ObservabilityService, recording
+// the encoding of the incoming messages.
+func (m *Metrics) InboundContextDecorators() []func(context.Context, binding.Message) context.Context {
+	return []func(context.Context, binding.Message) context.Context{
+		func(ctx context.Context, msg binding.Message) context.Context {
+			return context.WithValue(ctx, encodingKey{}, msg.ReadEncoding())
+		},
+	}
+}
+
+// RecordReceivedMalformedEvent implements client.ObservabilityService.
+func (m *Metrics) RecordReceivedMalformedEvent(ctx context.Context, err error) {
+	l := m.labels("", inboundEncoding(ctx))
+	m.received.WithLabelValues(l...).Inc()
+	m.results.WithLabelValues(append(l, directionInbound, resultNACK)...).Inc()
+}
+
+// RecordCallingInvoker implements client.ObservabilityService.
+func (m *Metrics) RecordCallingInvoker(ctx context.Context, e *event.Event) (context.Context, func(errOrResult error)) {
+	var eventType string
+	size := -1
+	if e != nil {
+		eventType = e.Type()
+		size = len(e.Data())
+	}
+	return ctx, m.startHandling(m.labels(eventType, inboundEncoding(ctx)), size)
+}
+
+// RecordSendingEvent implements client.ObservabilityService.
+func (m *Metrics) RecordSendingEvent(ctx context.Context, e event.Event) (context.Context, func(errOrResult error)) {
+	return ctx, m.startSending(m.labels(e.Type(), binding.PreferredEventEncoding(ctx)), len(e.Data()))
+}
+
+// RecordRequestEvent implements client.ObservabilityService.
+func (m *Metrics) RecordRequestEvent(ctx context.Context, e event.Event) (context.Context, func(errOrResult error, event *event.Event)) {
+	done := m.startSending(m.labels(e.Type(), binding.PreferredEventEncoding(ctx)), len(e.Data()))
+	return ctx, func(errOrResult error, _ *event.Event) {
+		done(errOrResult)
+	}
+}
+
+// startSending records an outbound event, and returns the function recording
+// its result. A negative size means the size of the data is unknown.
+func (m *Metrics) startSending(l []string, size int) func(error) {
+	m.sent.WithLabelValues(l...).Inc()
+	if size >= 0 {
+		m.size.WithLabelValues(append(l, directionOutbound)...).Observe(float64(size))
+	}
+	return func(errOrResult error) {
+		var retries *http.RetriesResult
+		if protocol.ResultAs(errOrResult, &retries) && len(retries.Attempts) > 0 {
+			m.retries.WithLabelValues(l...).Add(float64(len(retries.Attempts)))
+		}
+		m.results.WithLabelValues(append(l, directionOutbound, resultOf(errOrResult))...).Inc()
+	}
+}
+
+// startHandling records an inbound event, and returns the function recording
+// the result of its handling. A negative size means the size of the data is
+// unknown.
+func (m *Metrics) startHandling(l []string, size int) func(error) {
+	start := time.Now()
+	m.received.WithLabelValues(l...).Inc()
+	if size >= 0 {
+		m.size.WithLabelValues(append(l, directionInbound)...).Observe(float64(size))
+	}
+	inFlight := m.inFlight.WithLabelValues(l...)
+	inFlight.Inc()
+	return func(errOrResult error) {
+		inFlight.Dec()
+		m.duration.WithLabelValues(l...).Observe(time.Since(start).Seconds())
+		m.results.WithLabelValues(append(l, directionInbound, resultOf(errOrResult))...).Inc()
+	}
+}
+
+func (m *Metrics) labels(eventType string, encoding binding.Encoding) []string {
+	return []string{m.protocol, eventType, encoding.String()}
+}
+
+func inboundEncoding(ctx context.Context) binding.Encoding {
+	if encoding, ok := ctx.Value(encodingKey{}).(binding.Encoding); ok {
+		return encoding
+	}
+	return binding.EncodingUnknown
+}
+
+func resultOf(errOrResult error) string {
+	switch {
+	case protocol.IsACK(errOrResult):
+		return resultACK
+	case protocol.IsNACK(errOrResult):
+		return resultNACK
+	default:
+		return resultUndelivered
+	}
+}
+
+// messageLabels returns the type and the data size of the event in msg, when
+// they can be read without consuming msg. The size is negative if unknown.
+func messageLabels(msg binding.Message) (string, int) {
+	if em, ok := msg.(*binding.EventMessage); ok {
+		e := (*event.Event)(em)
+		return e.Type(), len(e.Data())
+	}
+	var eventType string
+	if reader, ok := msg.(binding.MessageMetadataReader); ok {
+		if _, v := reader.GetAttribute(spec.Type); v != nil {
+			eventType, _ = types.Format(v)
+		}
+	}
+	return eventType, -1
+}
@@ -0,0 +1,187 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/client"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/cloudevents/sdk-go/v2/protocol/gochan"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+)
+
+func newTestEvent() event.Event {
+	e := event.New()
+	e.SetID("1")
+	e.SetType("com.example.test")
+	e.SetSource("/test")
+	_ = e.SetData(event.TextPlain, "hello")
+	return e
+}
+
+func TestClient(t *testing.T) {
+	m := New(WithProtocol("gochan"))
+	c, err := client.New(gochan.New(), client.WithObservabilityService(m))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handled := make(chan struct{}, 2)
+	go func() {
+		_ = c.StartReceiver(ctx, func(e event.Event) protocol.Result {
+			defer func() { handled <- struct{}{} }()
+			if e.ID() == "2" {
+				return protocol.ResultNACK
+			}
+			return nil
+		})
+	}()
+
+	require.True(t, protocol.IsACK(c.Send(binding.WithForceStructured(context.Background()), newTestEvent())))
+	e := newTestEvent()
+	e.SetID("2")
+	require.True(t, protocol.IsACK(c.Send(context.Background(), e)))
+	for i := 0; i < 2; i++ {
+		select {
+		case <-handled:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for the events")
+		}
+	}
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.sent.WithLabelValues("gochan", "com.example.test", "structured")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.sent.WithLabelValues("gochan", "com.example.test", "binary")))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.results.WithLabelValues("gochan", "com.example.test", "binary", "outbound", "ack"))+
+		testutil.ToFloat64(m.results.WithLabelValues("gochan", "com.example.test", "structured", "outbound", "ack")))
+	// gochan passes the events as they were sent
+	require.Equal(t, 2.0, testutil.ToFloat64(m.received.WithLabelValues("gochan", "com.example.test", "event")))
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(m.results.WithLabelValues("gochan", "com.example.test", "event", "inbound", "nack")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.results.WithLabelValues("gochan", "com.example.test", "event", "inbound", "ack")))
+	require.Equal(t, 0.0, testutil.ToFloat64(m.inFlight.WithLabelValues("gochan", "com.example.test", "event")))
+	require.Equal(t, 1, testutil.CollectAndCount(m, "cloudevents_handler_duration_seconds"))
+}
+
+type failingSender struct {
+	result error
+}
+
+func (s failingSender) Send(ctx context.Context, m binding.Message, transformers ...binding.Transformer) error {
+	return s.result
+}
+
+func TestWrapSender(t *testing.T) {
+	m := New(WithProtocol("http"), WithNamespace("test"))
+	unavailable := http.NewResult(503, "%w", protocol.ResultNACK)
+	retries := http.NewRetriesResult(unavailable, 2, time.Now(), []protocol.Result{unavailable, unavailable})
+	s := m.WrapSender(failingSender{result: retries})
+
+	e := newTestEvent()
+	require.Equal(t, retries, s.Send(context.Background(), binding.ToMessage(&e)))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.sent.WithLabelValues("http", "com.example.test", "binary")))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.retries.WithLabelValues("http", "com.example.test", "binary")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.results.WithLabelValues("http", "com.example.test", "binary", "outbound", "nack")))
+
+	s = m.WrapSender(failingSender{result: errors.New("connection refused")})
+	require.Error(t, s.Send(context.Background(), binding.ToMessage(&e)))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.results.WithLabelValues("http", "com.example.test", "binary", "outbound", "undelivered")))
+}
+
+func TestWrapReceiver(t *testing.T) {
+	m := New(WithProtocol("gochan"))
+	ch := gochan.New()
+	r := m.WrapReceiver(ch)
+
+	e := newTestEvent()
+	go func() {
+		_ = ch.Send(context.Background(), binding.ToMessage(&e))
+	}()
+	msg, err := r.Receive(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.inFlight.WithLabelValues("gochan", "com.example.test", "event")))
+	require.NoError(t, msg.Finish(nil))
+	require.Equal(t, 0.0, testutil.ToFloat64(m.inFlight.WithLabelValues("gochan", "com.example.test", "event")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.results.WithLabelValues("gochan", "com.example.test", "event", "inbound", "ack")))
+}
+
+// protocolMock is a Receiver, Responder, Opener and Closer.
+type protocolMock struct {
+	messages chan binding.Message
+	opened   bool
+	closed   bool
+}
+
+func (p *protocolMock) Receive(ctx context.Context) (binding.Message, error) {
+	return <-p.messages, nil
+}
+
+func (p *protocolMock) Respond(ctx context.Context) (binding.Message, protocol.ResponseFn, error) {
+	return <-p.messages, func(context.Context, binding.Message, protocol.Result, ...binding.Transformer) error { return nil }, nil
+}
+
+func (p *protocolMock) OpenInbound(ctx context.Context) error {
+	p.opened = true
+	return nil
+}
+
+func (p *protocolMock) Close(ctx context.Context) error {
+	p.closed = true
+	return nil
+}
+
+func TestWrap_OptionalInterfaces(t *testing.T) {
+	m := New(WithProtocol("mock"))
+
+	s := m.WrapSender(failingSender{})
+	_, ok := s.(protocol.Closer)
+	require.False(t, ok)
+	s = m.WrapSender(gochan.Sender(make(chan binding.Message)))
+	require.Implements(t, (*protocol.Closer)(nil), s)
+	_, ok = s.(protocol.Opener)
+	require.False(t, ok)
+
+	r := m.WrapReceiver(gochan.Receiver(make(chan binding.Message)))
+	for _, ok := range []bool{isResponder(r), isOpener(r), isCloser(r)} {
+		require.False(t, ok)
+	}
+
+	p := &protocolMock{messages: make(chan binding.Message, 1)}
+	r = m.WrapReceiver(p)
+	require.True(t, isResponder(r) && isOpener(r) && isCloser(r))
+	require.NoError(t, r.(protocol.Opener).OpenInbound(context.Background()))
+	require.True(t, p.opened)
+	require.NoError(t, r.(protocol.Closer).Close(context.Background()))
+	require.True(t, p.closed)
+
+	e := newTestEvent()
+	p.messages <- binding.ToMessage(&e)
+	msg, _, err := r.(protocol.Responder).Respond(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.inFlight.WithLabelValues("mock", "com.example.test", "event")))
+	require.NoError(t, msg.Finish(protocol.ResultNACK))
+	require.Equal(t, 0.0, testutil.ToFloat64(m.inFlight.WithLabelValues("mock", "com.example.test", "event")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.results.WithLabelValues("mock", "com.example.test", "event", "inbound", "nack")))
+}
+
+func isResponder(r protocol.Receiver) bool {
+	_, ok := r.(protocol.Responder)
+	return ok
+}
+
+func isOpener(r protocol.Receiver) bool {
+	_, ok := r.(protocol.Opener)
+	return ok
+}
+
+func isCloser(r protocol.Receiver) bool {
+	_, ok := r.(protocol.Closer)
+	return ok
+}
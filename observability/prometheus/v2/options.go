@@ -0,0 +1,32 @@
+package prometheus
+
+// Option is the function signature required to be considered an prometheus.Option.
+type Option func(*Metrics)
+
+// WithNamespace sets the namespace of the metric names. Default is "cloudevents".
+func WithNamespace(namespace string) Option {
+	return func(m *Metrics) {
+		m.namespace = namespace
+	}
+}
+
+// WithProtocol sets the value of the protocol label, e.g. "http" or "kafka".
+func WithProtocol(protocol string) Option {
+	return func(m *Metrics) {
+		m.protocol = protocol
+	}
+}
+
+// WithDurationBuckets sets the buckets, in seconds, of the handler duration histogram.
+func WithDurationBuckets(buckets []float64) Option {
+	return func(m *Metrics) {
+		m.durationBuckets = buckets
+	}
+}
+
+// WithSizeBuckets sets the buckets, in bytes, of the event data size histogram.
+func WithSizeBuckets(buckets []float64) Option {
+	return func(m *Metrics) {
+		m.sizeBuckets = buckets
+	}
+}
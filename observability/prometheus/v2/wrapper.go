@@ -0,0 +1,167 @@
+package prometheus
+
+import (
+	"context"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+type sender struct {
+	protocol.Sender
+	metrics *Metrics
+}
+
+// The variants of sender forwarding the optional interfaces of the wrapped
+// sender, so that the type assertions of the callers still succeed.
+type (
+	senderOpener struct {
+		*sender
+		protocol.Opener
+	}
+	senderCloser struct {
+		*sender
+		protocol.Closer
+	}
+	senderOpenerCloser struct {
+		*sender
+		protocol.Opener
+		protocol.Closer
+	}
+)
+
+// WrapSender returns a protocol.Sender recording the messages sent with s, for
+// the protocols used without a client. The data size is only recorded for the
+// messages created with binding.ToMessage.
+// The returned sender is also a protocol.Opener and a protocol.Closer when s
+// is.
+func (m *Metrics) WrapSender(s protocol.Sender) protocol.Sender {
+	w := &sender{Sender: s, metrics: m}
+	opener, isOpener := s.(protocol.Opener)
+	closer, isCloser := s.(protocol.Closer)
+	switch {
+	case isOpener && isCloser:
+		return &senderOpenerCloser{sender: w, Opener: opener, Closer: closer}
+	case isOpener:
+		return &senderOpener{sender: w, Opener: opener}
+	case isCloser:
+		return &senderCloser{sender: w, Closer: closer}
+	}
+	return w
+}
+
+func (s *sender) Send(ctx context.Context, msg binding.Message, transformers ...binding.Transformer) error {
+	encoding := msg.ReadEncoding()
+	if encoding == binding.EncodingEvent {
+		encoding = binding.PreferredEventEncoding(ctx)
+	}
+	eventType, size := messageLabels(msg)
+	done := s.metrics.startSending(s.metrics.labels(eventType, encoding), size)
+	err := s.Sender.Send(ctx, msg, transformers...)
+	done(err)
+	return err
+}
+
+type receiver struct {
+	protocol.Receiver
+	metrics *Metrics
+}
+
+// responder records the messages received with Respond, like receiver.
+type responder struct {
+	protocol.Responder
+	metrics *Metrics
+}
+
+// The variants of receiver forwarding the optional interfaces of the wrapped
+// receiver, so that the type assertions of the callers, like client.New,
+// still succeed.
+type (
+	receiverOpener struct {
+		*receiver
+		protocol.Opener
+	}
+	receiverCloser struct {
+		*receiver
+		protocol.Closer
+	}
+	receiverOpenerCloser struct {
+		*receiver
+		protocol.Opener
+		protocol.Closer
+	}
+	receiverResponder struct {
+		*receiver
+		*responder
+	}
+	receiverResponderOpener struct {
+		*receiver
+		*responder
+		protocol.Opener
+	}
+	receiverResponderCloser struct {
+		*receiver
+		*responder
+		protocol.Closer
+	}
+	receiverResponderOpenerCloser struct {
+		*receiver
+		*responder
+		protocol.Opener
+		protocol.Closer
+	}
+)
+
+// WrapReceiver returns a protocol.Receiver recording the messages received
+// with r, for the protocols used without a client. A message is handled until
+// it is finished, with the error passed to Finish as the result.
+// The returned receiver is also a protocol.Responder, recording the messages
+// received with Respond, a protocol.Opener and a protocol.Closer when r is.
+func (m *Metrics) WrapReceiver(r protocol.Receiver) protocol.Receiver {
+	w := &receiver{Receiver: r, metrics: m}
+	opener, isOpener := r.(protocol.Opener)
+	closer, isCloser := r.(protocol.Closer)
+	if rs, ok := r.(protocol.Responder); ok {
+		wr := &responder{Responder: rs, metrics: m}
+		switch {
+		case isOpener && isCloser:
+			return &receiverResponderOpenerCloser{receiver: w, responder: wr, Opener: opener, Closer: closer}
+		case isOpener:
+			return &receiverResponderOpener{receiver: w, responder: wr, Opener: opener}
+		case isCloser:
+			return &receiverResponderCloser{receiver: w, responder: wr, Closer: closer}
+		}
+		return &receiverResponder{receiver: w, responder: wr}
+	}
+	switch {
+	case isOpener && isCloser:
+		return &receiverOpenerCloser{receiver: w, Opener: opener, Closer: closer}
+	case isOpener:
+		return &receiverOpener{receiver: w, Opener: opener}
+	case isCloser:
+		return &receiverCloser{receiver: w, Closer: closer}
+	}
+	return w
+}
+
+func (r *receiver) Receive(ctx context.Context) (binding.Message, error) {
+	msg, err := r.Receiver.Receive(ctx)
+	if err != nil {
+		return msg, err
+	}
+	return r.metrics.handling(msg), nil
+}
+
+func (r *responder) Respond(ctx context.Context) (binding.Message, protocol.ResponseFn, error) {
+	msg, respFn, err := r.Responder.Respond(ctx)
+	if err != nil {
+		return msg, respFn, err
+	}
+	return r.metrics.handling(msg), respFn, nil
+}
+
+// handling records msg as handled until it is finished.
+func (m *Metrics) handling(msg binding.Message) binding.Message {
+	eventType, size := messageLabels(msg)
+	return binding.WithFinish(msg, m.startHandling(m.labels(eventType, msg.ReadEncoding()), size))
+}
@@ -0,0 +1,70 @@
+package amqp
+
+import (
+	"context"
+
+	"github.com/Azure/go-amqp"
+)
+
+// ProtocolContext holds the AMQP metadata of a received message.
+type ProtocolContext struct {
+	// Address is the source address of the receiver link.
+	Address             string
+	DeliveryTag         []byte
+	Header              *amqp.MessageHeader
+	DeliveryAnnotations amqp.Annotations
+	Annotations         amqp.Annotations
+	Properties          *amqp.MessageProperties
+}
+
+// NewProtocolContext creates a new ProtocolContext from an amqp.Message
+// received from the link of address.
+func NewProtocolContext(address string, msg *amqp.Message) ProtocolContext {
+	if msg == nil {
+		return ProtocolContext{Address: address}
+	}
+	return ProtocolContext{
+		Address:             address,
+		DeliveryTag:         msg.DeliveryTag,
+		Header:              msg.Header,
+		DeliveryAnnotations: msg.DeliveryAnnotations,
+		Annotations:         msg.Annotations,
+		Properties:          msg.Properties,
+	}
+}
+
+// Opaque key type used to store ProtocolContext
+type protocolContextKeyType struct{}
+
+var protocolContextKey = protocolContextKeyType{}
+
+// WithProtocolContext returns a context with the given ProtocolContext into the provided context object.
+func WithProtocolContext(ctx context.Context, pctx ProtocolContext) context.Context {
+	return context.WithValue(ctx, protocolContextKey, pctx)
+}
+
+// ProtocolContextFrom pulls a ProtocolContext out of a context, or returns a
+// zero ProtocolContext.
+func ProtocolContextFrom(ctx context.Context) ProtocolContext {
+	if pctx, ok := ctx.Value(protocolContextKey).(ProtocolContext); ok {
+		return pctx
+	}
+	return ProtocolContext{}
+}
+
+type targetAddressKeyType struct{}
+
+// WithTargetAddress returns a context with the address the message is sent to,
+// instead of the target address of the sender link.
+func WithTargetAddress(ctx context.Context, address string) context.Context {
+	return context.WithValue(ctx, targetAddressKeyType{}, address)
+}
+
+// TargetAddressFrom pulls the address set with WithTargetAddress out of a
+// context, or returns an empty string.
+func TargetAddressFrom(ctx context.Context) string {
+	if address, ok := ctx.Value(targetAddressKeyType{}).(string); ok {
+		return address
+	}
+	return ""
+}
@@ -1,4 +1,25 @@
 /*
 Package amqp implements an AMQP binding using pack.ag/amqp module
+
+Messages received by a receiver link in the second settle mode, set with
+WithReceiverSettleMode(amqp.ModeSecond), support the exactly-once delivery:
+see binding.ExactlyOnceMessage. The client invoker and the senders of this
+package call Received once the message is delivered, and Finish once it's
+settled.
+
+The sender side of the flow is limited by the go-amqp module: Sender.Send
+returns when the peer settles the delivery, so the sender can't delay its
+ack-of-ack until the message is forwarded. Use the first settle mode for the
+sender links.
+
+The sender sends the messages to the target address of its link, or to the
+address set in the context with WithTargetAddress or held by the extension set
+with WithTargetExtension: the links to the other addresses are opened on the
+session of the sender and cached.
+
+The received messages are settled with the disposition mapped from the
+protocol.Result they're finished with, see Message.Finish and Result: the
+messages not acknowledged are rejected, unless the receiver function returns a
+Result with the released or modified outcome to have them redelivered.
 */
 package amqp
@@ -9,4 +9,5 @@ require (
 	github.com/cloudevents/sdk-go/v2 v2.0.0
 	github.com/google/go-cmp v0.4.1 // indirect
 	github.com/stretchr/testify v1.5.1
+	go.opencensus.io v0.22.0
 )
@@ -0,0 +1,168 @@
+package amqp
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/Azure/go-amqp"
+)
+
+const defaultLinkCacheSize = 64
+
+// senderLink is the subset of amqp.Sender used by the sender.
+type senderLink interface {
+	Send(ctx context.Context, msg *amqp.Message) error
+	Close(ctx context.Context) error
+}
+
+// linkCache holds the sender links opened to the target addresses of the
+// messages. When the cache is full, the least recently used link is evicted: it
+// is closed once the sends using it are done.
+type linkCache struct {
+	size int
+
+	mux   sync.Mutex
+	links map[string]*list.Element
+	lru   *list.List
+}
+
+type linkCacheEntry struct {
+	address string
+	link    senderLink
+	// refs counts the users of the link, which is closed when it is no longer
+	// cached and refs drops to 0
+	refs   int
+	cached bool
+	closed bool
+}
+
+func newLinkCache(size int) *linkCache {
+	return &linkCache{
+		size:  size,
+		links: make(map[string]*list.Element),
+		lru:   list.New(),
+	}
+}
+
+// get returns the link to address, opening it with open if it's not cached,
+// and the function releasing it, to invoke when the link is no longer used.
+// The link is opened without holding the lock of the cache: if the same link is
+// opened concurrently, the first one cached is used and the others are closed.
+func (c *linkCache) get(address string, open func() (senderLink, error)) (senderLink, func(), error) {
+	c.mux.Lock()
+	if e, ok := c.links[address]; ok {
+		entry := c.acquire(e)
+		c.mux.Unlock()
+		return entry.link, func() { c.release(entry) }, nil
+	}
+	c.mux.Unlock()
+
+	link, err := open()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.mux.Lock()
+	if e, ok := c.links[address]; ok {
+		entry := c.acquire(e)
+		c.mux.Unlock()
+		_ = link.Close(context.Background())
+		return entry.link, func() { c.release(entry) }, nil
+	}
+	entry := &linkCacheEntry{address: address, link: link, refs: 1, cached: true}
+	c.links[address] = c.lru.PushFront(entry)
+	var evicted []senderLink
+	for c.lru.Len() > c.size {
+		if unused := c.uncache(c.lru.Back()); unused != nil {
+			evicted = append(evicted, unused)
+		}
+	}
+	c.mux.Unlock()
+
+	for _, unused := range evicted {
+		_ = unused.Close(context.Background())
+	}
+	return link, func() { c.release(entry) }, nil
+}
+
+// acquire marks the link of e as used and recently used. It must be called
+// holding the lock.
+func (c *linkCache) acquire(e *list.Element) *linkCacheEntry {
+	c.lru.MoveToFront(e)
+	entry := e.Value.(*linkCacheEntry)
+	entry.refs++
+	return entry
+}
+
+// release marks the link of entry as no longer used by the caller of get,
+// closing it if it's no longer cached and used.
+func (c *linkCache) release(entry *linkCacheEntry) {
+	c.mux.Lock()
+	entry.refs--
+	closing := entry.refs == 0 && !entry.cached && !entry.closed
+	if closing {
+		entry.closed = true
+	}
+	c.mux.Unlock()
+
+	if closing {
+		_ = entry.link.Close(context.Background())
+	}
+}
+
+// uncache drops e from the cache, returning its link if it must be closed
+// because it's not used. It must be called holding the lock.
+func (c *linkCache) uncache(e *list.Element) senderLink {
+	entry := e.Value.(*linkCacheEntry)
+	c.lru.Remove(e)
+	delete(c.links, entry.address)
+	entry.cached = false
+	if entry.refs > 0 || entry.closed {
+		return nil
+	}
+	entry.closed = true
+	return entry.link
+}
+
+// remove drops link from the cache, e.g. because it was detached by the peer.
+// The link is closed once it's released by its users.
+func (c *linkCache) remove(address string, link senderLink) {
+	c.mux.Lock()
+	e, ok := c.links[address]
+	if !ok || e.Value.(*linkCacheEntry).link != link {
+		c.mux.Unlock()
+		return
+	}
+	link = c.uncache(e)
+	c.mux.Unlock()
+
+	if link != nil {
+		_ = link.Close(context.Background())
+	}
+}
+
+// close closes all the cached links, also the ones in use.
+func (c *linkCache) close(ctx context.Context) error {
+	c.mux.Lock()
+	var links []senderLink
+	for e := c.lru.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*linkCacheEntry)
+		entry.cached = false
+		if !entry.closed {
+			entry.closed = true
+			links = append(links, entry.link)
+		}
+	}
+	c.links = make(map[string]*list.Element)
+	c.lru.Init()
+	c.mux.Unlock()
+
+	var err error
+	for _, link := range links {
+		if closeErr := link.Close(ctx); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
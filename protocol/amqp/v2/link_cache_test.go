@@ -0,0 +1,154 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Azure/go-amqp"
+	"github.com/stretchr/testify/require"
+)
+
+type linkMock struct {
+	address string
+
+	mux    sync.Mutex
+	closed bool
+}
+
+func (l *linkMock) Send(ctx context.Context, msg *amqp.Message) error {
+	return nil
+}
+
+func (l *linkMock) Close(ctx context.Context) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.closed = true
+	return nil
+}
+
+func (l *linkMock) isClosed() bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return l.closed
+}
+
+func TestLinkCache(t *testing.T) {
+	cache := newLinkCache(2)
+	opened := map[string]*linkMock{}
+	get := func(address string) (senderLink, func()) {
+		link, release, err := cache.get(address, func() (senderLink, error) {
+			l := &linkMock{address: address}
+			opened[address] = l
+			return l, nil
+		})
+		require.NoError(t, err)
+		return link, release
+	}
+	use := func(address string) senderLink {
+		link, release := get(address)
+		release()
+		return link
+	}
+
+	a := use("a")
+	b := use("b")
+	require.Same(t, a, use("a"))
+	require.Len(t, opened, 2)
+
+	// b is the least recently used link
+	use("c")
+	require.True(t, opened["b"].isClosed())
+	require.False(t, opened["a"].isClosed())
+	require.NotSame(t, b, use("b"))
+	require.True(t, opened["a"].isClosed())
+	require.False(t, opened["c"].isClosed())
+
+	// A removed link is opened again
+	c := use("c")
+	cache.remove("c", c)
+	require.True(t, opened["c"].isClosed())
+	require.NotSame(t, c, use("c"))
+
+	require.NoError(t, cache.close(context.Background()))
+	require.True(t, opened["b"].isClosed())
+	require.True(t, opened["c"].isClosed())
+}
+
+func TestLinkCache_inUse(t *testing.T) {
+	cache := newLinkCache(1)
+	open := func(address string) func() (senderLink, error) {
+		return func() (senderLink, error) {
+			return &linkMock{address: address}, nil
+		}
+	}
+
+	a, releaseA, err := cache.get("a", open("a"))
+	require.NoError(t, err)
+	b, releaseB, err := cache.get("b", open("b"))
+	require.NoError(t, err)
+	// a is evicted, but it's closed only when released
+	require.False(t, a.(*linkMock).isClosed())
+	releaseA()
+	require.True(t, a.(*linkMock).isClosed())
+
+	// A removed link is closed only when released
+	cache.remove("b", b)
+	require.False(t, b.(*linkMock).isClosed())
+	releaseB()
+	require.True(t, b.(*linkMock).isClosed())
+}
+
+func TestLinkCache_concurrentOpen(t *testing.T) {
+	cache := newLinkCache(2)
+	_, releaseB, err := cache.get("b", func() (senderLink, error) {
+		return &linkMock{address: "b"}, nil
+	})
+	require.NoError(t, err)
+	releaseB()
+
+	// The link is opened without holding the lock, so the cached links are
+	// available meanwhile
+	opening := make(chan struct{})
+	unblock := make(chan struct{})
+	first := &linkMock{address: "a"}
+	done := make(chan senderLink)
+	go func() {
+		link, release, err := cache.get("a", func() (senderLink, error) {
+			close(opening)
+			<-unblock
+			return first, nil
+		})
+		require.NoError(t, err)
+		release()
+		done <- link
+	}()
+	<-opening
+	_, releaseB, err = cache.get("b", nil)
+	require.NoError(t, err)
+	releaseB()
+
+	// The same link opened concurrently is closed in favour of the cached one
+	second := &linkMock{address: "a"}
+	link, release, err := cache.get("a", func() (senderLink, error) {
+		return second, nil
+	})
+	require.NoError(t, err)
+	release()
+	require.Same(t, second, link)
+	close(unblock)
+	require.Same(t, second, <-done)
+	require.True(t, first.isClosed())
+	require.False(t, second.isClosed())
+}
+
+func TestLinkCache_openError(t *testing.T) {
+	cache := newLinkCache(2)
+	openErr := errors.New("link refused")
+	_, _, err := cache.get("a", func() (senderLink, error) {
+		return nil, openErr
+	})
+	require.Equal(t, openErr, err)
+	require.Len(t, cache.links, 0)
+}
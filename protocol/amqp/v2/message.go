@@ -5,15 +5,23 @@ import (
 	"context"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/Azure/go-amqp"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/format"
 	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 )
 
-const prefix = "cloudEvents:" // Name prefix for AMQP properties that hold CE attributes.
+const (
+	prefix = "cloudEvents:" // Name prefix for AMQP properties that hold CE attributes.
+
+	// partitionKeyAnnotation is the message annotation holding the partition
+	// key, as used by brokers like Azure Event Hubs.
+	partitionKeyAnnotation = "x-opt-partition-key"
+)
 
 var (
 	// Use the package path as AMQP error condition name
@@ -23,15 +31,27 @@ var (
 
 // Message implements binding.Message by wrapping an *amqp.Message.
 // This message *can* be read several times safely
+//
+// Message implements binding.ExactlyOnceMessage too: the QoS 2 flow requires
+// a receiver link in the second settle mode, set with
+// WithReceiverSettleMode(amqp.ModeSecond), otherwise
+// the message is settled as soon as it's accepted.
 type Message struct {
 	AMQP *amqp.Message
 
 	version spec.Version
 	format  format.Format
+
+	protocolContext *ProtocolContext
+
+	receivedMux sync.Mutex
+	received    bool
 }
 
 // NewMessage wrap an *amqp.Message in a binding.Message.
 // The returned message *can* be read several times safely
+// In a binary message without partitionkey extension, the x-opt-partition-key
+// annotation or the group-id property is read as the partitionkey extension.
 func NewMessage(message *amqp.Message) *Message {
 	if message.Properties != nil && format.IsFormat(message.Properties.ContentType) {
 		return &Message{AMQP: message, format: format.Lookup(message.Properties.ContentType)}
@@ -44,6 +64,9 @@ func NewMessage(message *amqp.Message) *Message {
 
 var _ binding.Message = (*Message)(nil)
 var _ binding.MessageMetadataReader = (*Message)(nil)
+var _ extensions.TraceContextReader = (*Message)(nil)
+var _ binding.ProtocolContextMessage = (*Message)(nil)
+var _ binding.ExactlyOnceMessage = (*Message)(nil)
 
 func getSpecVersion(message *amqp.Message) spec.Version {
 	if sv, ok := message.ApplicationProperties[specs.PrefixedSpecVersionName()]; ok {
@@ -54,6 +77,17 @@ func getSpecVersion(message *amqp.Message) spec.Version {
 	return nil
 }
 
+// ReadTraceContext implements extensions.TraceContextReader, reading the W3C
+// traceparent and tracestate application properties.
+func (m *Message) ReadTraceContext() (extensions.DistributedTracingExtension, bool) {
+	tp, ok := m.AMQP.ApplicationProperties[extensions.TraceParentHeader].(string)
+	if !ok {
+		return extensions.DistributedTracingExtension{}, false
+	}
+	ts, _ := m.AMQP.ApplicationProperties[extensions.TraceStateHeader].(string)
+	return extensions.DistributedTracingExtension{TraceParent: tp, TraceState: ts}, true
+}
+
 func (m *Message) ReadEncoding() binding.Encoding {
 	if m.version != nil {
 		return binding.EncodingBinary
@@ -97,6 +131,11 @@ func (m *Message) ReadBinary(ctx context.Context, encoder binding.BinaryWriter)
 			return err
 		}
 	}
+	if key := m.partitionKey(); key != "" {
+		if err = encoder.SetExtension(extensions.PartitionKeyExtension, key); err != nil {
+			return err
+		}
+	}
 
 	data := m.AMQP.GetData()
 	if len(data) != 0 { // Some data
@@ -117,15 +156,64 @@ func (m *Message) GetAttribute(k spec.Kind) (spec.Attribute, interface{}) {
 }
 
 func (m *Message) GetExtension(name string) interface{} {
+	if name == extensions.PartitionKeyExtension {
+		if key := m.partitionKey(); key != "" {
+			return key
+		}
+	}
 	return m.AMQP.ApplicationProperties[prefix+name]
 }
 
+// partitionKey returns the partition key of the native AMQP fields, when it's
+// not shadowed by a partitionkey application property.
+func (m *Message) partitionKey() string {
+	if _, ok := m.AMQP.ApplicationProperties[prefix+extensions.PartitionKeyExtension]; ok {
+		return ""
+	}
+	if key, ok := m.AMQP.Annotations[partitionKeyAnnotation].(string); ok && key != "" {
+		return key
+	}
+	if m.AMQP.Properties != nil {
+		return m.AMQP.Properties.GroupID
+	}
+	return ""
+}
+
+// WithProtocolContext implements binding.ProtocolContextMessage, attaching the
+// ProtocolContext of the received message.
+func (m *Message) WithProtocolContext(ctx context.Context) context.Context {
+	if m.protocolContext == nil {
+		return ctx
+	}
+	return WithProtocolContext(ctx, *m.protocolContext)
+}
+
+// Received implements binding.ExactlyOnceMessage.Received, accepting the
+// message. settle is invoked when the peer settles the delivery, i.e. the
+// ack-of-ack of the accepted disposition.
+func (m *Message) Received(settle func(error)) {
+	m.receivedMux.Lock()
+	m.received = true
+	m.receivedMux.Unlock()
+	go func() {
+		// With the second settle mode, Accept waits the settlement of the peer
+		settle(m.AMQP.Accept())
+	}()
+}
+
+// Finish settles the message with the disposition of the protocol.Result err:
+// ACK results are accepted, the Result errors of this package are settled with
+// their Outcome, and NACK results and the other errors are rejected. The
+// redelivery is opt-in: finish the message with NewResult(Released, ...) or
+// NewModifiedResult to have it redelivered.
+// If the message was already accepted by Received, Finish doesn't send another
+// disposition.
 func (m *Message) Finish(err error) error {
-	if err != nil {
-		return m.AMQP.Reject(&amqp.Error{
-			Condition:   condition,
-			Description: err.Error(),
-		})
+	m.receivedMux.Lock()
+	received := m.received
+	m.receivedMux.Unlock()
+	if received {
+		return nil
 	}
-	return m.AMQP.Accept()
+	return dispose(m.AMQP, err)
 }
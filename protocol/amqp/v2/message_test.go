@@ -6,9 +6,11 @@ import (
 
 	"github.com/Azure/go-amqp"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	. "github.com/cloudevents/sdk-go/v2/test"
 )
 
@@ -54,3 +56,52 @@ func TestNewMessage_message_unknown(t *testing.T) {
 	got := NewMessage(message)
 	require.Equal(t, binding.EncodingUnknown, got.ReadEncoding())
 }
+
+func TestPartitionKey(t *testing.T) {
+	e := FullEvent()
+	extensions.SetPartitionKey(&e, "key")
+
+	m := &amqp.Message{}
+	require.NoError(t, WriteMessage(binding.WithForceBinary(context.TODO()), binding.ToMessage(&e), m))
+	require.Equal(t, "key", m.Properties.GroupID)
+	require.Equal(t, "key", m.Annotations[partitionKeyAnnotation])
+
+	delete(m.ApplicationProperties, prefix+extensions.PartitionKeyExtension)
+	got, err := binding.ToEvent(context.TODO(), NewMessage(m))
+	require.NoError(t, err)
+	key, _ := extensions.GetPartitionKey(*got)
+	require.Equal(t, "key", key)
+
+	delete(m.Annotations, partitionKeyAnnotation)
+	m.Properties.GroupID = "group"
+	got, err = binding.ToEvent(context.TODO(), NewMessage(m))
+	require.NoError(t, err)
+	key, _ = extensions.GetPartitionKey(*got)
+	require.Equal(t, "group", key)
+}
+
+func TestWriteStructuredAsIs(t *testing.T) {
+	structured := []byte(`{"specversion":"1.0","id":"testid","type":"example.type","source":"example/uri","data":{"hello":"world"}}`)
+	in := amqp.NewMessage(structured)
+	in.Properties = &amqp.MessageProperties{ContentType: event.ApplicationCloudEventsJSON, GroupID: "key"}
+	require.Equal(t, binding.EncodingStructured, NewMessage(in).ReadEncoding())
+
+	m := &amqp.Message{}
+	require.NoError(t, WriteMessage(context.TODO(), NewMessage(in), m))
+	require.Equal(t, binding.EncodingStructured, NewMessage(m).ReadEncoding())
+	require.Equal(t, structured, m.GetData())
+	require.Equal(t, "key", m.Properties.GroupID)
+}
+
+func TestTraceContext(t *testing.T) {
+	ctx, span := trace.StartSpan(context.TODO(), "test")
+	defer span.End()
+
+	e := FullEvent()
+	m := &amqp.Message{}
+	require.NoError(t, WriteMessage(ctx, binding.ToMessage(&e), m))
+
+	tc, ok := NewMessage(m).ReadTraceContext()
+	require.True(t, ok)
+	require.Equal(t, extensions.FromSpanContext(span.SpanContext()), tc)
+}
@@ -1,6 +1,9 @@
 package amqp
 
 import (
+	"errors"
+	"time"
+
 	"github.com/Azure/go-amqp"
 )
 
@@ -44,5 +47,95 @@ func WithReceiverLinkOption(opt amqp.LinkOption) Option {
 	}
 }
 
+// WithSenderAddress sets the target address of the sender link, instead of the
+// queue of the protocol
+func WithSenderAddress(address string) Option {
+	return func(t *Protocol) error {
+		t.senderAddress = address
+		return nil
+	}
+}
+
+// WithReceiverAddress sets the source address of the receiver link, instead of
+// the queue of the protocol
+func WithReceiverAddress(address string) Option {
+	return func(t *Protocol) error {
+		t.receiverAddress = address
+		return nil
+	}
+}
+
+// WithSenderOptions sets the options of the sender of the protocol
+func WithSenderOptions(opts ...SenderOptionFunc) Option {
+	return func(t *Protocol) error {
+		t.senderOpts = append(t.senderOpts, opts...)
+		return nil
+	}
+}
+
+// WithReceiverCredit sets the link credit of the receiver link, i.e. the
+// maximum number of messages prefetched and not yet settled
+func WithReceiverCredit(credit uint32) Option {
+	return func(t *Protocol) error {
+		if credit == 0 {
+			return errors.New("the receiver link credit must be greater than 0")
+		}
+		return WithReceiverLinkOption(amqp.LinkCredit(credit))(t)
+	}
+}
+
+// WithReceiverBatching enables the batching of the dispositions of the receiver
+// link: they're sent when the batch size equals the link credit or after maxAge,
+// instead of after each message
+func WithReceiverBatching(maxAge time.Duration) Option {
+	return func(t *Protocol) error {
+		t.receiverLinkOpts = append(t.receiverLinkOpts, amqp.LinkBatching(true), amqp.LinkBatchMaxAge(maxAge))
+		return nil
+	}
+}
+
+// WithSenderSettleMode sets the settle mode of the sender links,
+// e.g. amqp.ModeSettled to send the messages pre-settled, at most once
+func WithSenderSettleMode(mode amqp.SenderSettleMode) Option {
+	return WithSenderLinkOption(amqp.LinkSenderSettle(mode))
+}
+
+// WithReceiverSettleMode sets the settle mode of the receiver link,
+// e.g. amqp.ModeSecond to receive the messages exactly once
+func WithReceiverSettleMode(mode amqp.ReceiverSettleMode) Option {
+	return WithReceiverLinkOption(amqp.LinkReceiverSettle(mode))
+}
+
+// WithDurability sets the durability of the target of the sender links and of
+// the source of the receiver link
+func WithDurability(durability amqp.Durability) Option {
+	return func(t *Protocol) error {
+		t.senderLinkOpts = append(t.senderLinkOpts, amqp.LinkTargetDurability(durability))
+		t.receiverLinkOpts = append(t.receiverLinkOpts, amqp.LinkSourceDurability(durability))
+		return nil
+	}
+}
+
 // SenderOptionFunc is the type of amqp.Sender options
 type SenderOptionFunc func(sender *sender)
+
+// WithTargetExtension sends each message to the address held by the extension
+// name, when the address is not set with WithTargetAddress. The messages
+// without the extension are sent to the target address of the sender link.
+// The sender must be created with NewSenderFromSession.
+func WithTargetExtension(name string) SenderOptionFunc {
+	return func(s *sender) {
+		s.targetExtension = name
+	}
+}
+
+// WithLinkCacheSize sets the maximum number of links opened to the addresses
+// different from the target address of the sender link. When the limit is
+// reached, the least recently used link is closed. The default is 64.
+func WithLinkCacheSize(size int) SenderOptionFunc {
+	return func(s *sender) {
+		if size > 0 {
+			s.linkCacheSize = size
+		}
+	}
+}
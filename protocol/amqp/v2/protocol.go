@@ -14,6 +14,9 @@ type Protocol struct {
 	sessionOpts      []amqp.SessionOption
 	senderLinkOpts   []amqp.LinkOption
 	receiverLinkOpts []amqp.LinkOption
+	senderOpts       []SenderOptionFunc
+	senderAddress    string
+	receiverAddress  string
 
 	// AMQP
 	Client      *amqp.Client
@@ -30,11 +33,16 @@ type Protocol struct {
 }
 
 // NewProtocolFromClient creates a new amqp transport.
+// The messages are sent to and received from queue, unless the addresses are
+// set with WithSenderAddress and WithReceiverAddress. The sender can send to
+// other addresses over the same session, see WithTargetAddress.
 func NewProtocolFromClient(client *amqp.Client, session *amqp.Session, queue string, opts ...Option) (*Protocol, error) {
 	t := &Protocol{
 		Node:             queue,
 		senderLinkOpts:   []amqp.LinkOption(nil),
 		receiverLinkOpts: []amqp.LinkOption(nil),
+		senderAddress:    queue,
+		receiverAddress:  queue,
 		Client:           client,
 		Session:          session,
 	}
@@ -42,19 +50,17 @@ func NewProtocolFromClient(client *amqp.Client, session *amqp.Session, queue str
 		return nil, err
 	}
 
-	t.senderLinkOpts = append(t.senderLinkOpts, amqp.LinkTargetAddress(queue))
-
 	// Create a sender
-	amqpSender, err := session.NewSender(t.senderLinkOpts...)
+	s, err := NewSenderFromSession(session, t.senderAddress, t.senderLinkOpts, t.senderOpts...)
 	if err != nil {
 		_ = client.Close()
 		_ = session.Close(context.Background())
 		return nil, err
 	}
-	t.Sender = NewSender(amqpSender).(*sender)
+	t.Sender = s.(*sender)
 	t.SenderContextDecorators = []func(context.Context) context.Context{}
 
-	t.receiverLinkOpts = append(t.receiverLinkOpts, amqp.LinkSourceAddress(t.Node))
+	t.receiverLinkOpts = append(t.receiverLinkOpts, amqp.LinkSourceAddress(t.receiverAddress))
 	amqpReceiver, err := t.Session.NewReceiver(t.receiverLinkOpts...)
 	if err != nil {
 		return nil, err
@@ -101,7 +107,7 @@ func (t *Protocol) Close(ctx context.Context) (err error) {
 		return t.Client.Close()
 	} else {
 		if t.Sender != nil {
-			if err = t.Sender.amqp.Close(ctx); err != nil {
+			if err = t.Sender.Close(ctx); err != nil {
 				return
 			}
 		}
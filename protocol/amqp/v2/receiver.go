@@ -10,8 +10,15 @@ import (
 	"github.com/cloudevents/sdk-go/v2/protocol"
 )
 
+// amqpReceiver is the part of amqp.Receiver used by receiver.
+type amqpReceiver interface {
+	Receive(ctx context.Context) (*amqp.Message, error)
+	Address() string
+	Close(ctx context.Context) error
+}
+
 // receiver wraps an amqp.Receiver as a binding.Receiver
-type receiver struct{ amqp *amqp.Receiver }
+type receiver struct{ amqp amqpReceiver }
 
 func (r *receiver) Receive(ctx context.Context) (binding.Message, error) {
 	m, err := r.amqp.Receive(ctx)
@@ -22,7 +29,10 @@ func (r *receiver) Receive(ctx context.Context) (binding.Message, error) {
 		return nil, err
 	}
 
-	return NewMessage(m), nil
+	msg := NewMessage(m)
+	pctx := NewProtocolContext(r.amqp.Address(), m)
+	msg.protocolContext = &pctx
+	return msg, nil
 }
 
 // NewReceiver create a new Receiver which wraps an amqp.Receiver in a binding.Receiver
@@ -0,0 +1,53 @@
+package amqp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/go-amqp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+)
+
+type amqpReceiverMock struct {
+	address string
+	msg     *amqp.Message
+}
+
+func (r *amqpReceiverMock) Receive(ctx context.Context) (*amqp.Message, error) {
+	return r.msg, nil
+}
+
+func (r *amqpReceiverMock) Address() string {
+	return r.address
+}
+
+func (r *amqpReceiverMock) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestReceiver_ProtocolContext(t *testing.T) {
+	msg := &amqp.Message{
+		DeliveryTag:         []byte("tag"),
+		Header:              &amqp.MessageHeader{DeliveryCount: 2},
+		DeliveryAnnotations: amqp.Annotations{"x-opt-delivery": "annotation"},
+		Properties:          &amqp.MessageProperties{MessageID: "1"},
+	}
+	r := &receiver{amqp: &amqpReceiverMock{address: "test", msg: msg}}
+
+	m, err := r.Receive(context.TODO())
+	require.NoError(t, err)
+	want := ProtocolContext{
+		Address:             "test",
+		DeliveryTag:         []byte("tag"),
+		Header:              msg.Header,
+		DeliveryAnnotations: msg.DeliveryAnnotations,
+		Properties:          msg.Properties,
+	}
+	require.Equal(t, want, ProtocolContextFrom(binding.WithProtocolContext(context.TODO(), m)))
+
+	// The client wraps the received messages, e.g. to record the metrics
+	wrapped := binding.WithFinish(m, nil)
+	require.Equal(t, want, ProtocolContextFrom(binding.WithProtocolContext(context.TODO(), wrapped)))
+}
@@ -0,0 +1,129 @@
+package amqp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Azure/go-amqp"
+
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+// Outcome is the AMQP disposition of a received message that was not accepted.
+type Outcome int
+
+const (
+	// Rejected means the message is invalid and must not be redelivered
+	Rejected Outcome = iota
+	// Released means the message was not processed and can be redelivered
+	Released
+	// Modified means the message was not processed and can be redelivered,
+	// with the delivery annotations of the Result
+	Modified
+)
+
+// NewResult returns a fully populated amqp Result that should be used as
+// a transport.Result, finishing the received message with outcome.
+func NewResult(outcome Outcome, messageFmt string, args ...interface{}) protocol.Result {
+	return &Result{
+		Outcome: outcome,
+		Format:  messageFmt,
+		Args:    args,
+	}
+}
+
+// NewModifiedResult returns a Result finishing the received message with the
+// modified outcome.
+func NewModifiedResult(deliveryFailed, undeliverableHere bool, annotations amqp.Annotations, messageFmt string, args ...interface{}) protocol.Result {
+	return &Result{
+		Outcome:           Modified,
+		DeliveryFailed:    deliveryFailed,
+		UndeliverableHere: undeliverableHere,
+		Annotations:       annotations,
+		Format:            messageFmt,
+		Args:              args,
+	}
+}
+
+// Result wraps the fields required to settle a received message with a
+// disposition other than accepted.
+type Result struct {
+	Outcome Outcome
+	// DeliveryFailed, UndeliverableHere and Annotations are the fields of the
+	// modified outcome
+	DeliveryFailed    bool
+	UndeliverableHere bool
+	Annotations       amqp.Annotations
+
+	Format string
+	Args   []interface{}
+}
+
+// make sure Result implements error.
+var _ error = (*Result)(nil)
+
+// Is returns if the target error is a Result type checking target.
+func (e *Result) Is(target error) bool {
+	if o, ok := target.(*Result); ok {
+		return e.Outcome == o.Outcome
+	}
+
+	// A message not accepted is a NACK
+	if o, ok := target.(*protocol.Receipt); ok {
+		return !o.ACK
+	}
+
+	// Allow for wrapped errors.
+	err := fmt.Errorf(e.Format, e.Args...)
+	return errors.Is(err, target)
+}
+
+// Error returns the string that is formed by using the format string with the
+// provided args.
+func (e *Result) Error() string {
+	return fmt.Sprintf("%s: %v", e.Outcome, fmt.Errorf(e.Format, e.Args...))
+}
+
+func (o Outcome) String() string {
+	switch o {
+	case Rejected:
+		return "rejected"
+	case Released:
+		return "released"
+	case Modified:
+		return "modified"
+	}
+	return fmt.Sprintf("Outcome(%d)", int(o))
+}
+
+// disposer is the subset of amqp.Message settling a received message.
+type disposer interface {
+	Accept() error
+	Reject(e *amqp.Error) error
+	Release() error
+	Modify(deliveryFailed, undeliverableHere bool, messageAnnotations amqp.Annotations) error
+}
+
+// dispose settles msg with the disposition of result:
+//   - ACK results are accepted
+//   - Result errors are settled with their outcome
+//   - NACK results and the other errors are rejected
+func dispose(msg disposer, result error) error {
+	if protocol.IsACK(result) {
+		return msg.Accept()
+	}
+
+	var r *Result
+	if protocol.ResultAs(result, &r) {
+		switch r.Outcome {
+		case Released:
+			return msg.Release()
+		case Modified:
+			return msg.Modify(r.DeliveryFailed, r.UndeliverableHere, r.Annotations)
+		}
+	}
+	return msg.Reject(&amqp.Error{
+		Condition:   condition,
+		Description: result.Error(),
+	})
+}
@@ -0,0 +1,107 @@
+package amqp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/go-amqp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+type disposerMock struct {
+	disposition       string
+	rejectErr         *amqp.Error
+	deliveryFailed    bool
+	undeliverableHere bool
+	annotations       amqp.Annotations
+}
+
+func (d *disposerMock) Accept() error {
+	d.disposition = "accepted"
+	return nil
+}
+
+func (d *disposerMock) Reject(e *amqp.Error) error {
+	d.disposition = "rejected"
+	d.rejectErr = e
+	return nil
+}
+
+func (d *disposerMock) Release() error {
+	d.disposition = "released"
+	return nil
+}
+
+func (d *disposerMock) Modify(deliveryFailed, undeliverableHere bool, messageAnnotations amqp.Annotations) error {
+	d.disposition = "modified"
+	d.deliveryFailed = deliveryFailed
+	d.undeliverableHere = undeliverableHere
+	d.annotations = messageAnnotations
+	return nil
+}
+
+func TestDispose(t *testing.T) {
+	tests := []struct {
+		name              string
+		result            error
+		disposition       string
+		deliveryFailed    bool
+		undeliverableHere bool
+		annotations       amqp.Annotations
+	}{{
+		name:        "nil",
+		disposition: "accepted",
+	}, {
+		name:        "ACK",
+		result:      protocol.ResultACK,
+		disposition: "accepted",
+	}, {
+		name:        "NACK",
+		result:      protocol.NewReceipt(false, "invalid event"),
+		disposition: "rejected",
+	}, {
+		name:        "error",
+		result:      errors.New("processing failed"),
+		disposition: "rejected",
+	}, {
+		name:        "rejected",
+		result:      NewResult(Rejected, "invalid event"),
+		disposition: "rejected",
+	}, {
+		name:        "released",
+		result:      NewResult(Released, "try later"),
+		disposition: "released",
+	}, {
+		name:              "modified",
+		result:            NewModifiedResult(false, true, amqp.Annotations{"x-opt-reason": "busy"}, "busy"),
+		disposition:       "modified",
+		undeliverableHere: true,
+		annotations:       amqp.Annotations{"x-opt-reason": "busy"},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &disposerMock{}
+			require.NoError(t, dispose(msg, tt.result))
+			require.Equal(t, tt.disposition, msg.disposition)
+			require.Equal(t, tt.deliveryFailed, msg.deliveryFailed)
+			require.Equal(t, tt.undeliverableHere, msg.undeliverableHere)
+			require.Equal(t, tt.annotations, msg.annotations)
+			if tt.disposition == "rejected" {
+				require.Equal(t, condition, msg.rejectErr.Condition)
+				require.Equal(t, tt.result.Error(), msg.rejectErr.Description)
+			}
+		})
+	}
+}
+
+func TestResult_Is(t *testing.T) {
+	result := NewResult(Released, "busy: %w", protocol.ResultNACK)
+	require.True(t, protocol.IsNACK(result))
+	require.False(t, protocol.IsACK(result))
+	require.True(t, errors.Is(result, &Result{Outcome: Released}))
+	require.False(t, errors.Is(result, &Result{Outcome: Rejected}))
+	require.Equal(t, "released: busy: ", result.Error())
+}
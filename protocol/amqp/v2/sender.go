@@ -2,41 +2,144 @@ package amqp
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/Azure/go-amqp"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/cloudevents/sdk-go/v2/types"
 )
 
 // sender wraps an amqp.Sender as a binding.Sender
 type sender struct {
 	amqp *amqp.Sender
+
+	// session opens the links to the addresses different from the target
+	// address of amqp, see NewSenderFromSession
+	session         *amqp.Session
+	linkOpts        []amqp.LinkOption
+	linkCacheSize   int
+	links           *linkCache
+	targetExtension string
 }
 
+// Send implements Sender.Send, sending in to the address of ctx set with
+// WithTargetAddress, to the address of the extension set with WithTargetExtension
+// or to the target address of the sender link.
 func (s *sender) Send(ctx context.Context, in binding.Message, transformers ...binding.Transformer) error {
 	var err error
 	defer func() { _ = in.Finish(err) }()
+
+	var amqpMessage *amqp.Message
+	var extensionAddress string
 	if m, ok := in.(*Message); ok { // Already an AMQP message.
-		err = s.amqp.Send(ctx, m.AMQP)
-		return err
+		amqpMessage = m.AMQP
+		if s.targetExtension != "" {
+			if extensionAddress, err = s.readTargetExtension(m); err != nil {
+				return err
+			}
+		}
+	} else {
+		if s.targetExtension != "" {
+			transformers = append(transformers, binding.TransformerFunc(func(r binding.MessageMetadataReader, w binding.MessageMetadataWriter) error {
+				var err error
+				extensionAddress, err = s.readTargetExtension(r)
+				return err
+			}))
+		}
+		amqpMessage = &amqp.Message{}
+		if err = WriteMessage(ctx, in, amqpMessage, transformers...); err != nil {
+			return err
+		}
 	}
 
-	var amqpMessage amqp.Message
-	err = WriteMessage(ctx, in, &amqpMessage, transformers...)
+	address := TargetAddressFrom(ctx)
+	if address == "" {
+		address = extensionAddress
+	}
+	link, release, err := s.link(address)
 	if err != nil {
 		return err
 	}
+	defer release()
 
-	err = s.amqp.Send(ctx, &amqpMessage)
+	err = link.Send(ctx, amqpMessage)
+	if err == nil {
+		// The peer accepted the message: forward the acknowledgment to a QoS 2 message
+		err = binding.SettleExactlyOnce(ctx, in)
+	} else if link != s.amqp && isLinkClosed(err) {
+		s.links.remove(address, link)
+	}
 	return err
 }
 
+func (s *sender) readTargetExtension(reader binding.MessageMetadataReader) (string, error) {
+	v := reader.GetExtension(s.targetExtension)
+	if types.IsZero(v) {
+		return "", nil
+	}
+	return types.Format(v)
+}
+
+// link returns the link to address, opening it if it's not the sender link,
+// and the function releasing it when the message is sent.
+func (s *sender) link(address string) (senderLink, func(), error) {
+	if address == "" || address == s.amqp.Address() {
+		return s.amqp, func() {}, nil
+	}
+	if s.session == nil {
+		return nil, nil, fmt.Errorf("cannot send to the address %q: the sender has no session, use NewSenderFromSession", address)
+	}
+	return s.links.get(address, func() (senderLink, error) {
+		opts := append(append([]amqp.LinkOption(nil), s.linkOpts...), amqp.LinkTargetAddress(address))
+		link, err := s.session.NewSender(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open a link to the address %q: %w", address, err)
+		}
+		return link, nil
+	})
+}
+
+// Close closes the sender link and the links opened to the other addresses.
+func (s *sender) Close(ctx context.Context) error {
+	err := s.links.close(ctx)
+	if closeErr := s.amqp.Close(ctx); closeErr != nil {
+		return closeErr
+	}
+	return err
+}
+
+func isLinkClosed(err error) bool {
+	var detachErr *amqp.DetachError
+	return errors.As(err, &detachErr) || errors.Is(err, amqp.ErrLinkClosed) || errors.Is(err, amqp.ErrSessionClosed)
+}
+
 // NewSender creates a new Sender which wraps an amqp.Sender in a binding.Sender
 func NewSender(amqpSender *amqp.Sender, options ...SenderOptionFunc) protocol.Sender {
-	s := &sender{amqp: amqpSender}
+	s := &sender{amqp: amqpSender, linkCacheSize: defaultLinkCacheSize}
 	for _, o := range options {
 		o(s)
 	}
+	s.links = newLinkCache(s.linkCacheSize)
 	return s
 }
+
+// NewSenderFromSession creates a new Sender which opens a link with linkOpts to
+// address, and a link to each other address the messages are sent to, see
+// WithTargetAddress and WithTargetExtension. The links to the other addresses are
+// cached, see WithLinkCacheSize.
+func NewSenderFromSession(session *amqp.Session, address string, linkOpts []amqp.LinkOption, options ...SenderOptionFunc) (protocol.Sender, error) {
+	opts := append(append([]amqp.LinkOption(nil), linkOpts...), amqp.LinkTargetAddress(address))
+	amqpSender, err := session.NewSender(opts...)
+	if err != nil {
+		return nil, err
+	}
+	s := NewSender(amqpSender, options...).(*sender)
+	s.session = session
+	s.linkOpts = linkOpts
+	return s, nil
+}
+
+var _ protocol.Closer = (*sender)(nil)
@@ -10,15 +10,38 @@ import (
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/format"
 	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/types"
 )
 
 // WriteMessage fills the provided amqpMessage with the message m.
 // Using context you can tweak the encoding processing (more details on binding.Write documentation).
+// The partitionkey extension, if set, is mapped to the group-id property and to
+// the x-opt-partition-key message annotation.
+// The trace context of the span in ctx, if any, is written in the W3C
+// traceparent and tracestate application properties.
 func WriteMessage(ctx context.Context, m binding.Message, amqpMessage *amqp.Message, transformers ...binding.Transformer) error {
 	structuredWriter := (*amqpMessageWriter)(amqpMessage)
 	binaryWriter := (*amqpMessageWriter)(amqpMessage)
 
+	var partitionKey string
+	if len(transformers) == 0 && m.ReadEncoding() == binding.EncodingStructured {
+		// A transformer would prevent writing the structured message as is:
+		// read the partitionkey from the metadata of the message, if any
+		if r, ok := binding.UnwrapMessage(m).(binding.MessageMetadataReader); ok {
+			var err error
+			if partitionKey, err = extensions.ReadPartitionKey(r); err != nil {
+				return err
+			}
+		}
+	} else {
+		transformers = append(transformers, binding.TransformerFunc(func(r binding.MessageMetadataReader, w binding.MessageMetadataWriter) error {
+			var err error
+			partitionKey, err = extensions.ReadPartitionKey(r)
+			return err
+		}))
+	}
+
 	_, err := binding.Write(
 		ctx,
 		m,
@@ -26,6 +49,25 @@ func WriteMessage(ctx context.Context, m binding.Message, amqpMessage *amqp.Mess
 		binaryWriter,
 		transformers...,
 	)
+	if err == nil && partitionKey != "" {
+		if amqpMessage.Properties == nil {
+			amqpMessage.Properties = &amqp.MessageProperties{}
+		}
+		amqpMessage.Properties.GroupID = partitionKey
+		if amqpMessage.Annotations == nil {
+			amqpMessage.Annotations = make(amqp.Annotations)
+		}
+		amqpMessage.Annotations[partitionKeyAnnotation] = partitionKey
+	}
+	if tc, ok := extensions.TraceContextFromContext(ctx); ok && err == nil {
+		if amqpMessage.ApplicationProperties == nil {
+			amqpMessage.ApplicationProperties = make(map[string]interface{})
+		}
+		amqpMessage.ApplicationProperties[extensions.TraceParentHeader] = tc.TraceParent
+		if tc.TraceState != "" {
+			amqpMessage.ApplicationProperties[extensions.TraceStateHeader] = tc.TraceState
+		}
+	}
 	return err
 }
 
@@ -0,0 +1,174 @@
+package kafka_sarama
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+const defaultMaxInFlight = 1024
+
+var errAsyncSenderClosed = errors.New("async sender closed")
+
+// AsyncSender implements binding.Sender that sends messages to a specific topic using sarama.AsyncProducer.
+// AsyncSender.Send returns as soon as the message is queued in the producer: the
+// message is finished with the result of the delivery when the broker acks it,
+// or when the delivery fails.
+// The messages are batched following the Producer.Flush settings of the
+// sarama.Config, e.g. Producer.Flush.Messages, Producer.Flush.Bytes and
+// Producer.Flush.Frequency, the linger time of the batches.
+// Send blocks when too many messages are waiting the ack, see WithMaxInFlight.
+type AsyncSender struct {
+	topic          string
+	topicExtension string
+	keyExtractor   KeyExtractor
+	producer       sarama.AsyncProducer
+
+	// inFlight holds a token for each message waiting the ack
+	inFlight    chan struct{}
+	maxInFlight int
+
+	closedMux sync.RWMutex
+	closed    bool
+	results   sync.WaitGroup
+}
+
+// NewAsyncSender returns a binding.Sender that sends messages to a specific topic using sarama.AsyncProducer
+func NewAsyncSender(brokers []string, saramaConfig *sarama.Config, topic string, options ...AsyncSenderOptionFunc) (*AsyncSender, error) {
+	// Force these settings because they're required to finish the messages
+	saramaConfig.Producer.Return.Successes = true
+	saramaConfig.Producer.Return.Errors = true
+	producer, err := sarama.NewAsyncProducer(brokers, saramaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return makeAsyncSender(producer, topic, options...), nil
+}
+
+// NewAsyncSenderFromClient returns a binding.Sender that sends messages to a specific topic using sarama.AsyncProducer
+// The Producer.Return.Successes and Producer.Return.Errors settings of the client config must be true.
+func NewAsyncSenderFromClient(client sarama.Client, topic string, options ...AsyncSenderOptionFunc) (*AsyncSender, error) {
+	if c := client.Config(); !c.Producer.Return.Successes || !c.Producer.Return.Errors {
+		return nil, sarama.ConfigurationError("Producer.Return.Successes and Producer.Return.Errors must be true to be used in an AsyncSender")
+	}
+	producer, err := sarama.NewAsyncProducerFromClient(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return makeAsyncSender(producer, topic, options...), nil
+}
+
+// NewAsyncSenderFromAsyncProducer returns a binding.Sender that sends messages to a specific topic using sarama.AsyncProducer
+// The AsyncSender consumes the Successes and the Errors channels of the producer, that must be enabled.
+func NewAsyncSenderFromAsyncProducer(topic string, producer sarama.AsyncProducer, options ...AsyncSenderOptionFunc) (*AsyncSender, error) {
+	return makeAsyncSender(producer, topic, options...), nil
+}
+
+func makeAsyncSender(producer sarama.AsyncProducer, topic string, options ...AsyncSenderOptionFunc) *AsyncSender {
+	s := &AsyncSender{
+		topic:       topic,
+		producer:    producer,
+		maxInFlight: defaultMaxInFlight,
+	}
+	for _, o := range options {
+		o(s)
+	}
+	s.inFlight = make(chan struct{}, s.maxInFlight)
+
+	s.results.Add(2)
+	go func() {
+		defer s.results.Done()
+		for pm := range producer.Successes() {
+			s.finish(pm, nil)
+		}
+	}()
+	go func() {
+		defer s.results.Done()
+		for pe := range producer.Errors() {
+			s.finish(pe.Msg, pe.Err)
+		}
+	}()
+	return s
+}
+
+func (s *AsyncSender) finish(pm *sarama.ProducerMessage, err error) {
+	<-s.inFlight
+	if m, ok := pm.Metadata.(binding.Message); ok {
+		_ = m.Finish(err)
+	}
+}
+
+// Send implements Sender.Send, queueing m to be sent to the topic of ctx set
+// with cecontext.WithTopic, or to the topic of the extension set with
+// WithTopicExtension, or to the topic of the sender.
+// m is finished with the result of the delivery.
+func (s *AsyncSender) Send(ctx context.Context, m binding.Message, transformers ...binding.Transformer) (err error) {
+	defer func() {
+		if err != nil {
+			_ = m.Finish(err)
+		}
+	}()
+
+	kafkaMessage, err := newProducerMessage(ctx, s.topic, s.topicExtension, s.keyExtractor, m, transformers...)
+	if err != nil {
+		return err
+	}
+	kafkaMessage.Metadata = m
+
+	// Backpressure
+	select {
+	case s.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.closedMux.RLock()
+	defer s.closedMux.RUnlock()
+	if s.closed {
+		<-s.inFlight
+		return errAsyncSenderClosed
+	}
+	select {
+	case s.producer.Input() <- kafkaMessage:
+		return nil
+	case <-ctx.Done():
+		<-s.inFlight
+		return ctx.Err()
+	}
+}
+
+// Close flushes the queued messages and waits their results, until ctx is done.
+func (s *AsyncSender) Close(ctx context.Context) error {
+	s.closedMux.Lock()
+	if s.closed {
+		s.closedMux.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.closedMux.Unlock()
+
+	// If the AsyncSender was built with NewAsyncSenderFromClient, this Close will close only the producer,
+	// otherwise it will close the whole client
+	s.producer.AsyncClose()
+	done := make(chan struct{})
+	go func() {
+		s.results.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+var _ protocol.Sender = (*AsyncSender)(nil)
+var _ protocol.Closer = (*AsyncSender)(nil)
@@ -0,0 +1,117 @@
+package kafka_sarama
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/test"
+)
+
+type asyncProducerMock struct {
+	sarama.AsyncProducer
+	input     chan *sarama.ProducerMessage
+	successes chan *sarama.ProducerMessage
+	errors    chan *sarama.ProducerError
+}
+
+func newAsyncProducerMock() *asyncProducerMock {
+	return &asyncProducerMock{
+		input:     make(chan *sarama.ProducerMessage, 10),
+		successes: make(chan *sarama.ProducerMessage),
+		errors:    make(chan *sarama.ProducerError),
+	}
+}
+
+func (a *asyncProducerMock) AsyncClose() {
+	close(a.successes)
+	close(a.errors)
+}
+
+func (a *asyncProducerMock) Close() error {
+	a.AsyncClose()
+	return nil
+}
+
+func (a *asyncProducerMock) Input() chan<- *sarama.ProducerMessage {
+	return a.input
+}
+
+func (a *asyncProducerMock) Successes() <-chan *sarama.ProducerMessage {
+	return a.successes
+}
+
+func (a *asyncProducerMock) Errors() <-chan *sarama.ProducerError {
+	return a.errors
+}
+
+func TestAsyncSender(t *testing.T) {
+	producerMock := newAsyncProducerMock()
+	sender, err := NewAsyncSenderFromAsyncProducer("aaa", producerMock)
+	require.NoError(t, err)
+
+	finished := make(chan error, 2)
+	finish := func(err error) { finished <- err }
+
+	require.NoError(t, sender.Send(
+		WithMessageKey(context.TODO(), sarama.StringEncoder("hello")),
+		binding.WithFinish(test.FullMessage(), finish),
+	))
+	require.NoError(t, sender.Send(context.TODO(), binding.WithFinish(test.FullMessage(), finish)))
+
+	kafkaMsg := <-producerMock.input
+	require.Equal(t, "aaa", kafkaMsg.Topic)
+	require.Equal(t, sarama.StringEncoder("hello"), kafkaMsg.Key)
+	producerMock.successes <- kafkaMsg
+	require.NoError(t, <-finished)
+
+	sendErr := errors.New("boom")
+	producerMock.errors <- &sarama.ProducerError{Msg: <-producerMock.input, Err: sendErr}
+	require.Equal(t, sendErr, <-finished)
+
+	require.NoError(t, sender.Close(context.TODO()))
+	require.Error(t, sender.Send(context.TODO(), test.FullMessage()))
+}
+
+func TestAsyncSenderBackpressure(t *testing.T) {
+	producerMock := newAsyncProducerMock()
+	sender, err := NewAsyncSenderFromAsyncProducer("aaa", producerMock, WithMaxInFlight(1))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sender.Close(context.TODO())) }()
+
+	require.NoError(t, sender.Send(context.TODO(), test.FullMessage()))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	require.Equal(t, context.DeadlineExceeded, sender.Send(ctx, test.FullMessage()))
+
+	// The ack of the first message unblocks the sender
+	producerMock.successes <- <-producerMock.input
+	require.NoError(t, sender.Send(context.TODO(), test.FullMessage()))
+}
+
+func TestAsyncSenderWithSenderOptions(t *testing.T) {
+	producerMock := newAsyncProducerMock()
+	sender, err := NewAsyncSenderFromAsyncProducer("aaa", producerMock,
+		WithSenderOptions(WithTopicExtension("exstring"), WithKeyMapping(KeyFromSubject)))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sender.Close(context.TODO())) }()
+
+	// test.FullMessage has the exstring extension and the subject "topic"
+	require.NoError(t, sender.Send(context.TODO(), test.FullMessage()))
+	require.NoError(t, sender.Send(context.TODO(), test.MinMessage()))
+
+	kafkaMsg := <-producerMock.input
+	require.Equal(t, "exstring", kafkaMsg.Topic)
+	require.Equal(t, sarama.StringEncoder("topic"), kafkaMsg.Key)
+	producerMock.successes <- kafkaMsg
+
+	kafkaMsg = <-producerMock.input
+	require.Equal(t, "aaa", kafkaMsg.Topic)
+	producerMock.successes <- kafkaMsg
+}
@@ -0,0 +1,68 @@
+package kafka_sarama
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// ProtocolContext holds the Kafka metadata of a received message.
+type ProtocolContext struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Timestamp time.Time
+
+	// commit marks the offset of the message, see CommitMessage
+	commit func()
+}
+
+// NewProtocolContext creates a new ProtocolContext from a sarama.ConsumerMessage.
+func NewProtocolContext(cm *sarama.ConsumerMessage) ProtocolContext {
+	if cm == nil {
+		return ProtocolContext{}
+	}
+	return ProtocolContext{
+		Topic:     cm.Topic,
+		Partition: cm.Partition,
+		Offset:    cm.Offset,
+		Key:       cm.Key,
+		Timestamp: cm.Timestamp,
+	}
+}
+
+// Opaque key type used to store ProtocolContext
+type protocolContextKeyType struct{}
+
+var protocolContextKey = protocolContextKeyType{}
+
+// WithProtocolContext returns a context with the given ProtocolContext into the provided context object.
+func WithProtocolContext(ctx context.Context, pctx ProtocolContext) context.Context {
+	return context.WithValue(ctx, protocolContextKey, pctx)
+}
+
+// ProtocolContextFrom pulls a ProtocolContext out of a context, or returns a
+// zero ProtocolContext.
+func ProtocolContextFrom(ctx context.Context) ProtocolContext {
+	if pctx, ok := ctx.Value(protocolContextKey).(ProtocolContext); ok {
+		return pctx
+	}
+	return ProtocolContext{}
+}
+
+// CommitMessage marks the offset of the message received with ctx, the context
+// of the receiver function, as consumed. The offset is committed following the
+// Consumer.Offsets.AutoCommit settings of the sarama.Config, and when the
+// consumer group session ends.
+// Use it with WithManualCommit.
+func CommitMessage(ctx context.Context) error {
+	pctx := ProtocolContextFrom(ctx)
+	if pctx.commit == nil {
+		return errors.New("no kafka message to commit in the context")
+	}
+	pctx.commit()
+	return nil
+}
@@ -1,4 +1,18 @@
 /*
 Package kafka_sarama implements a Kafka binding using github.com/Shopify/sarama module
+
+Sender sends each message with a blocking round trip, while AsyncSender batches
+the messages using sarama.AsyncProducer, finishing them when the broker acks.
+
+The request-reply pattern is supported through the ReplyTopicHeader and the
+CorrelationIdHeader headers: use WithReplyTopic to enable Protocol.Request.
+
+TransactionalProcessor processes the consumed messages exactly once
+(consume-transform-produce): the messages produced by its handler and the
+consumed offset are committed in a single Kafka transaction. Otherwise the
+delivery is at-least-once: the duplicates of the produced messages can be
+reduced enabling Producer.Idempotent in the sarama.Config, and the consumed
+offsets can be committed after the produced messages are acked with
+WithManualCommit and CommitMessage.
 */
 package kafka_sarama
@@ -0,0 +1,141 @@
+package kafka_sarama
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+// KeyExtractor extracts the key of the producer message from the message
+// metadata. An empty key leaves the producer message without key.
+type KeyExtractor interface {
+	ExtractKey(reader binding.MessageMetadataReader) (string, error)
+}
+
+// KeyExtractorFunc is a function implementing KeyExtractor.
+type KeyExtractorFunc func(reader binding.MessageMetadataReader) (string, error)
+
+// ExtractKey implements KeyExtractor.ExtractKey.
+func (f KeyExtractorFunc) ExtractKey(reader binding.MessageMetadataReader) (string, error) {
+	return f(reader)
+}
+
+// eventKeyExtractor is implemented by the extractors that must read the whole
+// event: the message is converted to an event before being written.
+type eventKeyExtractor interface {
+	requiresEvent() bool
+}
+
+// KeyFromPartitionKey extracts the key from the partitionkey extension. This is
+// the default key mapping.
+var KeyFromPartitionKey KeyExtractor = KeyExtractorFunc(extensions.ReadPartitionKey)
+
+// KeyFromSubject extracts the key from the subject attribute.
+var KeyFromSubject = KeyFromAttribute(spec.Subject)
+
+// KeyFromAttribute extracts the key from the attribute of kind.
+func KeyFromAttribute(kind spec.Kind) KeyExtractor {
+	return KeyExtractorFunc(func(reader binding.MessageMetadataReader) (string, error) {
+		_, v := reader.GetAttribute(kind)
+		return formatKey(v)
+	})
+}
+
+// KeyFromExtension extracts the key from the extension name.
+func KeyFromExtension(name string) KeyExtractor {
+	return KeyExtractorFunc(func(reader binding.MessageMetadataReader) (string, error) {
+		return formatKey(reader.GetExtension(name))
+	})
+}
+
+// KeyFromEvent extracts the key with fn. The message is converted to an event,
+// if it's not already an event message, so fn can read the event data.
+func KeyFromEvent(fn func(e *event.Event) (string, error)) KeyExtractor {
+	return eventKeyExtractorFunc(fn)
+}
+
+type eventKeyExtractorFunc func(e *event.Event) (string, error)
+
+func (f eventKeyExtractorFunc) ExtractKey(reader binding.MessageMetadataReader) (string, error) {
+	if em, ok := reader.(*binding.EventMessage); ok {
+		return f((*event.Event)(em))
+	}
+	// WriteProducerMessage converts the message to an event before extracting the key
+	return "", nil
+}
+
+func (f eventKeyExtractorFunc) requiresEvent() bool {
+	return true
+}
+
+// KeyFromHash extracts the key as the hex encoded SHA-256 hash of the keys of
+// extractors, e.g. the hash of several attributes. The key is empty when all the
+// keys of extractors are empty.
+func KeyFromHash(extractors ...KeyExtractor) KeyExtractor {
+	return hashKeyExtractor(extractors)
+}
+
+type hashKeyExtractor []KeyExtractor
+
+func (h hashKeyExtractor) ExtractKey(reader binding.MessageMetadataReader) (string, error) {
+	hash := sha256.New()
+	empty := true
+	for _, extractor := range h {
+		key, err := extractor.ExtractKey(reader)
+		if err != nil {
+			return "", err
+		}
+		if key != "" {
+			empty = false
+		}
+		// The separator keeps apart ("ab", "c") and ("a", "bc")
+		_, _ = hash.Write([]byte(key))
+		_, _ = hash.Write([]byte{0})
+	}
+	if empty {
+		return "", nil
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (h hashKeyExtractor) requiresEvent() bool {
+	for _, extractor := range h {
+		if requiresEvent(extractor) {
+			return true
+		}
+	}
+	return false
+}
+
+func requiresEvent(extractor KeyExtractor) bool {
+	e, ok := extractor.(eventKeyExtractor)
+	return ok && e.requiresEvent()
+}
+
+func formatKey(v interface{}) (string, error) {
+	if types.IsZero(v) {
+		return "", nil
+	}
+	return types.Format(v)
+}
+
+type keyExtractorKey struct{}
+
+// WithKeyExtractor sets the KeyExtractor used by WriteProducerMessage, instead of
+// KeyFromPartitionKey.
+func WithKeyExtractor(ctx context.Context, extractor KeyExtractor) context.Context {
+	return context.WithValue(ctx, keyExtractorKey{}, extractor)
+}
+
+func keyExtractorFrom(ctx context.Context) KeyExtractor {
+	if extractor, ok := ctx.Value(keyExtractorKey{}).(KeyExtractor); ok && extractor != nil {
+		return extractor
+	}
+	return KeyFromPartitionKey
+}
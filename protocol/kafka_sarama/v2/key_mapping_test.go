@@ -0,0 +1,81 @@
+package kafka_sarama
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	bindingtest "github.com/cloudevents/sdk-go/v2/binding/test"
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+func TestKeyExtractors(t *testing.T) {
+	e := event.New()
+	e.SetID("id")
+	e.SetType("type")
+	e.SetSource("source")
+	e.SetSubject("subject")
+	e.SetExtension("tenant", "acme")
+	e.SetExtension("partitionkey", "pkey")
+	require.NoError(t, e.SetData(event.ApplicationJSON, map[string]string{"order": "42"}))
+
+	hash := sha256.Sum256([]byte("acme\x00subject\x00"))
+
+	tests := map[string]struct {
+		extractor KeyExtractor
+		want      string
+	}{
+		"partitionkey": {extractor: KeyFromPartitionKey, want: "pkey"},
+		"subject":      {extractor: KeyFromSubject, want: "subject"},
+		"attribute":    {extractor: KeyFromAttribute(spec.Type), want: "type"},
+		"extension":    {extractor: KeyFromExtension("tenant"), want: "acme"},
+		"missing":      {extractor: KeyFromExtension("missing"), want: ""},
+		"hash":         {extractor: KeyFromHash(KeyFromExtension("tenant"), KeyFromSubject), want: hex.EncodeToString(hash[:])},
+		"event": {extractor: KeyFromEvent(func(e *event.Event) (string, error) {
+			data := map[string]string{}
+			if err := e.DataAs(&data); err != nil {
+				return "", err
+			}
+			return data["order"], nil
+		}), want: "42"},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			messages := map[string]binding.Message{
+				"event":  binding.ToMessage(&e),
+				"binary": bindingtest.MustCreateMockBinaryMessage(e),
+			}
+			for name, m := range messages {
+				pm := &sarama.ProducerMessage{}
+				require.NoError(t, WriteProducerMessage(WithKeyExtractor(context.TODO(), tc.extractor), m, pm), name)
+				if tc.want == "" {
+					require.Nil(t, pm.Key, name)
+				} else {
+					require.Equal(t, sarama.StringEncoder(tc.want), pm.Key, name)
+				}
+			}
+		})
+	}
+}
+
+func TestSenderWithKeyMapping(t *testing.T) {
+	syncProducerMock := &syncProducerMock{}
+	sender, err := NewSenderFromSyncProducer("aaa", syncProducerMock, WithKeyMapping(KeyFromSubject))
+	require.NoError(t, err)
+
+	e := event.New()
+	e.SetID("id")
+	e.SetType("type")
+	e.SetSource("source")
+	e.SetSubject("subject")
+	require.NoError(t, sender.Send(context.TODO(), binding.ToMessage(&e)))
+
+	require.Len(t, syncProducerMock.sent, 1)
+	require.Equal(t, sarama.StringEncoder("subject"), syncProducerMock.sent[0].Key)
+}
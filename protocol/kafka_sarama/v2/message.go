@@ -8,6 +8,7 @@ import (
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/format"
 	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 
 	"github.com/Shopify/sarama"
 )
@@ -27,16 +28,29 @@ type Message struct {
 	ContentType string
 	format      format.Format
 	version     spec.Version
+
+	protocolContext *ProtocolContext
 }
 
 // Check if http.Message implements binding.Message
 var _ binding.Message = (*Message)(nil)
 var _ binding.MessageMetadataReader = (*Message)(nil)
+var _ extensions.TraceContextReader = (*Message)(nil)
+var _ binding.ProtocolContextMessage = (*Message)(nil)
 
 // NewMessageFromConsumerMessage returns a binding.Message that holds the provided ConsumerMessage.
 // The returned binding.Message *can* be read several times safely
 // This function *doesn't* guarantee that the returned binding.Message is always a kafka_sarama.Message instance
+// The ProtocolContext of cm is attached to the context of the receiver function.
 func NewMessageFromConsumerMessage(cm *sarama.ConsumerMessage) *Message {
+	return NewMessageFromConsumerMessageWithKeyAttribute(cm, "")
+}
+
+// NewMessageFromConsumerMessageWithKeyAttribute is like NewMessageFromConsumerMessage,
+// but the key of a binary message is mapped to the attribute or extension
+// keyAttribute, e.g. "partitionkey" or "subject", when the message doesn't set it.
+// An empty keyAttribute disables the mapping.
+func NewMessageFromConsumerMessageWithKeyAttribute(cm *sarama.ConsumerMessage, keyAttribute string) *Message {
 	var contentType string
 	headers := make(map[string][]byte, len(cm.Headers))
 	for _, r := range cm.Headers {
@@ -46,7 +60,16 @@ func NewMessageFromConsumerMessage(cm *sarama.ConsumerMessage) *Message {
 		}
 		headers[k] = r.Value
 	}
-	return NewMessage(cm.Value, contentType, headers)
+	if keyAttribute != "" && len(cm.Key) > 0 {
+		keyHeader := prefix + strings.ToLower(keyAttribute)
+		if _, ok := headers[keyHeader]; !ok {
+			headers[keyHeader] = cm.Key
+		}
+	}
+	m := NewMessage(cm.Value, contentType, headers)
+	pctx := NewProtocolContext(cm)
+	m.protocolContext = &pctx
+	return m
 }
 
 // NewMessage returns a binding.Message that holds the provided kafka message components.
@@ -76,6 +99,25 @@ func NewMessage(value []byte, contentType string, headers map[string][]byte) *Me
 	}
 }
 
+// WithProtocolContext implements binding.ProtocolContextMessage, attaching the
+// ProtocolContext of the consumer message the message was read from.
+func (m *Message) WithProtocolContext(ctx context.Context) context.Context {
+	if m.protocolContext == nil {
+		return ctx
+	}
+	return WithProtocolContext(ctx, *m.protocolContext)
+}
+
+// ReadTraceContext implements extensions.TraceContextReader, reading the W3C
+// traceparent and tracestate headers.
+func (m *Message) ReadTraceContext() (extensions.DistributedTracingExtension, bool) {
+	tp, ok := m.Headers[extensions.TraceParentHeader]
+	if !ok {
+		return extensions.DistributedTracingExtension{}, false
+	}
+	return extensions.DistributedTracingExtension{TraceParent: string(tp), TraceState: string(m.Headers[extensions.TraceStateHeader])}, true
+}
+
 func (m *Message) ReadEncoding() binding.Encoding {
 	if m.version != nil {
 		return binding.EncodingBinary
@@ -1,6 +1,7 @@
 package kafka_sarama_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/Shopify/sarama"
@@ -10,6 +11,7 @@ import (
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/format"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/test"
 )
 
@@ -87,3 +89,63 @@ func mustToSaramaConsumerHeaders(m map[string]string) []*sarama.RecordHeader {
 	}
 	return res
 }
+
+func TestNewMessage_KeyToPartitionKey(t *testing.T) {
+	cm := *binaryConsumerMessage
+	cm.Key = []byte("key-from-kafka")
+	e, err := binding.ToEvent(context.TODO(), kafka_sarama.NewMessageFromConsumerMessage(&cm))
+	require.NoError(t, err)
+	_, ok := extensions.GetPartitionKey(*e)
+	require.False(t, ok, "the key is not mapped by default")
+
+	e, err = binding.ToEvent(context.TODO(), kafka_sarama.NewMessageFromConsumerMessageWithKeyAttribute(&cm, extensions.PartitionKeyExtension))
+	require.NoError(t, err)
+	key, ok := extensions.GetPartitionKey(*e)
+	require.True(t, ok)
+	require.Equal(t, "key-from-kafka", key)
+
+	cm.Headers = append(cm.Headers, &sarama.RecordHeader{Key: []byte("ce_partitionkey"), Value: []byte("key-from-header")})
+	e, err = binding.ToEvent(context.TODO(), kafka_sarama.NewMessageFromConsumerMessageWithKeyAttribute(&cm, extensions.PartitionKeyExtension))
+	require.NoError(t, err)
+	key, _ = extensions.GetPartitionKey(*e)
+	require.Equal(t, "key-from-header", key)
+}
+
+func TestNewMessage_KeyToAttribute(t *testing.T) {
+	cm := *binaryConsumerMessage
+	cm.Key = []byte("key-from-kafka")
+	e, err := binding.ToEvent(context.TODO(), kafka_sarama.NewMessageFromConsumerMessageWithKeyAttribute(&cm, "tenant"))
+	require.NoError(t, err)
+	require.Equal(t, "key-from-kafka", e.Extensions()["tenant"])
+	_, ok := extensions.GetPartitionKey(*e)
+	require.False(t, ok)
+
+	// The attribute of the message has the precedence
+	e, err = binding.ToEvent(context.TODO(), kafka_sarama.NewMessageFromConsumerMessageWithKeyAttribute(&cm, "subject"))
+	require.NoError(t, err)
+	require.Equal(t, "receiverTopic", e.Subject())
+
+	e, err = binding.ToEvent(context.TODO(), kafka_sarama.NewMessageFromConsumerMessageWithKeyAttribute(&cm, ""))
+	require.NoError(t, err)
+	require.NotContains(t, e.Extensions(), "tenant")
+	_, ok = extensions.GetPartitionKey(*e)
+	require.False(t, ok)
+}
+
+func TestNewMessage_ProtocolContext(t *testing.T) {
+	cm := *binaryConsumerMessage
+	cm.Topic = "events"
+	cm.Partition = 2
+	cm.Offset = 42
+	cm.Key = []byte("key")
+
+	// The receiver wraps the message to mark it when finished
+	m := binding.WithFinish(kafka_sarama.NewMessageFromConsumerMessage(&cm), nil)
+	pctx := kafka_sarama.ProtocolContextFrom(binding.WithProtocolContext(context.TODO(), m))
+	require.Equal(t, kafka_sarama.ProtocolContext{
+		Topic:     "events",
+		Partition: 2,
+		Offset:    42,
+		Key:       []byte("key"),
+	}, pctx)
+}
@@ -2,11 +2,177 @@ package kafka_sarama
 
 import (
 	"context"
+	"regexp"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	"github.com/cloudevents/sdk-go/v2/protocol"
 )
 
 // SenderOptionFunc is the type of kafka_sarama.Sender options
 type SenderOptionFunc func(sender *Sender)
 
+// WithTopicExtension sends each message to the topic of its extension name,
+// when set, instead of the topic of the Sender. The topic set in the context
+// with cecontext.WithTopic has the precedence. Use WithSenderOptions to set it
+// on an AsyncSender.
+func WithTopicExtension(name string) SenderOptionFunc {
+	return func(sender *Sender) {
+		sender.topicExtension = name
+	}
+}
+
+// WithKeyMapping sets the KeyExtractor of the key of the sent messages, instead
+// of KeyFromPartitionKey. The KeyExtractor set in the context with
+// WithKeyExtractor has the precedence. Use WithSenderOptions to set it on an
+// AsyncSender.
+func WithKeyMapping(extractor KeyExtractor) SenderOptionFunc {
+	return func(sender *Sender) {
+		sender.keyExtractor = extractor
+	}
+}
+
+// AsyncSenderOptionFunc is the type of kafka_sarama.AsyncSender options
+type AsyncSenderOptionFunc func(sender *AsyncSender)
+
+// WithSenderOptions applies the Sender options, e.g. WithTopicExtension and
+// WithKeyMapping, to the AsyncSender.
+func WithSenderOptions(options ...SenderOptionFunc) AsyncSenderOptionFunc {
+	return func(sender *AsyncSender) {
+		s := &Sender{topic: sender.topic, topicExtension: sender.topicExtension, keyExtractor: sender.keyExtractor}
+		for _, o := range options {
+			o(s)
+		}
+		sender.topic, sender.topicExtension, sender.keyExtractor = s.topic, s.topicExtension, s.keyExtractor
+	}
+}
+
+// WithMaxInFlight sets the max number of messages waiting the ack of the
+// broker: when reached, AsyncSender.Send blocks. Default is 1024.
+func WithMaxInFlight(max int) AsyncSenderOptionFunc {
+	return func(sender *AsyncSender) {
+		if max > 0 {
+			sender.maxInFlight = max
+		}
+	}
+}
+
+// TransactionalProcessorOptionFunc is the type of
+// kafka_sarama.TransactionalProcessor options
+type TransactionalProcessorOptionFunc func(processor *TransactionalProcessor)
+
+// WithProcessorSenderOptions applies the Sender options, e.g.
+// WithTopicExtension and WithKeyMapping, to the Sender of the
+// TransactionalProcessor.
+func WithProcessorSenderOptions(options ...SenderOptionFunc) TransactionalProcessorOptionFunc {
+	return func(processor *TransactionalProcessor) {
+		for _, o := range options {
+			o(processor.sender)
+		}
+	}
+}
+
+// WithMaxAttempts bounds the processing of each consumed message to attempts:
+// when the handler fails attempts times, the message is passed to deadLetter,
+// and the consumption goes on if deadLetter succeeds. The attempts are counted
+// by each instance of the processor, from its start.
+func WithMaxAttempts(attempts int, deadLetter TransactionalDeadLetterFunc) TransactionalProcessorOptionFunc {
+	return func(processor *TransactionalProcessor) {
+		if attempts > 0 {
+			processor.maxAttempts = attempts
+			processor.deadLetter = deadLetter
+		}
+	}
+}
+
+// ReceiverOptionFunc is the type of kafka_sarama.Receiver options
+type ReceiverOptionFunc func(receiver *Receiver)
+
+// WithRetryInPlace retries the NACKed messages in place: the consumption of the
+// partition is paused until the message is ACKed or the params.MaxTries
+// retries are exhausted. The messages of a partition are then received one at a
+// time. When the retries are exhausted, the message is sent to the retry topics
+// or to the dead letter topic, if any, and it is skipped.
+func WithRetryInPlace(params cecontext.RetryParams) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.retryParams = &params
+	}
+}
+
+// WithRetryTopics sends the failed messages with sender to the chain of retry
+// topics, which are consumed by consumers of the same group: a message failed
+// after i retry topics is sent to topics[i], with the RetryAttemptHeader and
+// the RetryNotBeforeHeader headers, and its offset is marked. The consumption of
+// a partition is paused until the RetryNotBeforeHeader header time of the
+// message. At the end of the chain the message is sent to the dead letter topic,
+// if any, or it is skipped.
+// sender must honour cecontext.WithTopic, WithMessageKey and WithMessageHeaders,
+// e.g. kafka_sarama.Sender.
+func WithRetryTopics(sender protocol.Sender, topics ...RetryTopic) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.failureSender = sender
+		receiver.retryTopics = topics
+	}
+}
+
+// WithDeadLetterTopic sends the failed messages with sender to the dead letter
+// topic, with the OriginalTopicHeader, OriginalPartitionHeader,
+// OriginalOffsetHeader and FailureHeader headers, and marks their offsets.
+// sender must honour cecontext.WithTopic, WithMessageKey and WithMessageHeaders,
+// e.g. kafka_sarama.Sender.
+func WithDeadLetterTopic(sender protocol.Sender, topic string) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.failureSender = sender
+		receiver.deadLetterTopic = topic
+	}
+}
+
+// WithManualCommit disables the marking of the offsets of the ACKed messages:
+// the receiver function marks them with CommitMessage.
+func WithManualCommit() ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.manualCommit = true
+	}
+}
+
+// WithCommitOnShutdown waits, up to timeout, the messages in flight when the
+// consumer group session ends, e.g. on shutdown or rebalance, so that their
+// offsets are committed.
+func WithCommitOnShutdown(timeout time.Duration) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.shutdownTimeout = timeout
+	}
+}
+
+// WithKeyAttribute maps the key of the received binary messages to the
+// attribute or extension name, e.g. extensions.PartitionKeyExtension or
+// "subject", when the message doesn't set it. By default the key is not
+// mapped.
+func WithKeyAttribute(name string) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.keyAttribute = name
+	}
+}
+
+// WithPartitionsAssigned sets the hook invoked when partitions are assigned to
+// the consumer, before their consumption starts.
+func WithPartitionsAssigned(hook PartitionsHookFunc) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.onAssigned = hook
+	}
+}
+
+// WithPartitionsRevoked sets the hook invoked when partitions are revoked from
+// the consumer, e.g. on rebalance or shutdown, after their consumption stopped
+// and before the final commit of their offsets.
+func WithPartitionsRevoked(hook PartitionsHookFunc) ReceiverOptionFunc {
+	return func(receiver *Receiver) {
+		receiver.onRevoked = hook
+	}
+}
+
 // ProtocolOptionFunc is the type of kafka_sarama.Protocol options
 type ProtocolOptionFunc func(protocol *Protocol)
 
@@ -21,3 +187,67 @@ func WithSenderContextDecorators(decorator func(context.Context) context.Context
 		protocol.SenderContextDecorators = append(protocol.SenderContextDecorators, decorator)
 	}
 }
+
+// WithReplyTopic enables Protocol.Request, consuming the responses from the
+// topic. See kafka_sarama.Requester.
+func WithReplyTopic(topic string) ProtocolOptionFunc {
+	return func(protocol *Protocol) {
+		protocol.replyTopic = topic
+	}
+}
+
+// WithReceiverOptions configures the Consumer of the Protocol.
+func WithReceiverOptions(opts ...ReceiverOptionFunc) ProtocolOptionFunc {
+	return func(protocol *Protocol) {
+		protocol.receiverOptions = append(protocol.receiverOptions, opts...)
+	}
+}
+
+// WithRebalanceStrategy sets the Consumer.Group.Rebalance.GroupStrategies of
+// the sarama.Config of the Protocol client, in order of priority, e.g.
+// sarama.BalanceStrategySticky.
+// The sarama.Config is modified in place: with NewProtocolFromClient, it
+// affects all the users of the client, so use a client dedicated to the
+// Protocol.
+func WithRebalanceStrategy(strategies ...sarama.BalanceStrategy) ProtocolOptionFunc {
+	return func(protocol *Protocol) {
+		protocol.rebalanceStrategies = strategies
+	}
+}
+
+// WithInitialOffset sets the Consumer.Offsets.Initial of the sarama.Config of
+// the Protocol client, used when there is no committed offset:
+// sarama.OffsetNewest or sarama.OffsetOldest.
+// Like WithRebalanceStrategy, it modifies the sarama.Config in place.
+func WithInitialOffset(offset int64) ProtocolOptionFunc {
+	return func(protocol *Protocol) {
+		protocol.initialOffset = &offset
+	}
+}
+
+// WithReceiverTopics subscribes the Consumer of the Protocol to all the topics,
+// instead of the topic to receive from.
+func WithReceiverTopics(topics ...string) ProtocolOptionFunc {
+	return func(protocol *Protocol) {
+		protocol.receiverTopics = topics
+	}
+}
+
+// WithReceiverTopicPattern subscribes the Consumer of the Protocol to the topics
+// matching pattern, instead of the topic to receive from. See
+// NewPatternConsumerFromClient.
+func WithReceiverTopicPattern(pattern *regexp.Regexp, refreshInterval time.Duration) ProtocolOptionFunc {
+	return func(protocol *Protocol) {
+		protocol.receiverTopicPattern = pattern
+		protocol.receiverRefreshInterval = refreshInterval
+	}
+}
+
+// WithSenderTopicExtension sends each message to the topic of its extension
+// name, when set, instead of the topic to send to, that becomes optional. See
+// kafka_sarama.WithTopicExtension.
+func WithSenderTopicExtension(name string) ProtocolOptionFunc {
+	return func(protocol *Protocol) {
+		protocol.senderTopicExtension = name
+	}
+}
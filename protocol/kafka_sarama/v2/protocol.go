@@ -3,7 +3,10 @@ package kafka_sarama
 import (
 	"context"
 	"errors"
+	"regexp"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 
@@ -27,14 +30,27 @@ type Protocol struct {
 	// Sender options
 	SenderContextDecorators []func(context.Context) context.Context
 	senderTopic             string
+	senderTopicExtension    string
 
 	// Consumer
 	Consumer    *Consumer
 	consumerMux sync.Mutex
 
 	// Consumer options
-	receiverTopic   string
-	receiverGroupId string
+	receiverTopic           string
+	receiverTopics          []string
+	receiverTopicPattern    *regexp.Regexp
+	receiverRefreshInterval time.Duration
+	receiverGroupId         string
+	receiverOptions         []ReceiverOptionFunc
+
+	// Consumer group options, applied to the config of the client
+	rebalanceStrategies []sarama.BalanceStrategy
+	initialOffset       *int64
+
+	// Requester, set with WithReplyTopic
+	Requester  *Requester
+	replyTopic string
 }
 
 // NewProtocol creates a new kafka transport.
@@ -70,18 +86,41 @@ func NewProtocolFromClient(client sarama.Client, sendToTopic string, receiveFrom
 		return nil, err
 	}
 
-	if p.senderTopic == "" {
+	if p.senderTopic == "" && p.senderTopicExtension == "" {
 		return nil, errors.New("you didn't specify the topic to send to")
 	}
-	p.Sender, err = NewSenderFromClient(p.Client, p.senderTopic)
+	p.Sender, err = NewSenderFromClient(p.Client, p.senderTopic, WithTopicExtension(p.senderTopicExtension))
 	if err != nil {
 		return nil, err
 	}
 
-	if p.receiverTopic == "" {
+	if p.receiverTopic == "" && len(p.receiverTopics) == 0 && p.receiverTopicPattern == nil {
+		_ = p.Sender.Close(context.Background())
 		return nil, errors.New("you didn't specify the topic to receive from")
 	}
-	p.Consumer = NewConsumerFromClient(p.Client, p.receiverGroupId, p.receiverTopic)
+	if len(p.rebalanceStrategies) > 0 {
+		p.Client.Config().Consumer.Group.Rebalance.GroupStrategies = p.rebalanceStrategies
+	}
+	if p.initialOffset != nil {
+		p.Client.Config().Consumer.Offsets.Initial = *p.initialOffset
+	}
+	switch {
+	case p.receiverTopicPattern != nil:
+		p.Consumer = NewPatternConsumerFromClient(p.Client, p.receiverGroupId, p.receiverTopicPattern, p.receiverRefreshInterval, p.receiverOptions...)
+	case len(p.receiverTopics) > 0:
+		p.Consumer = NewMultiTopicConsumerFromClient(p.Client, p.receiverGroupId, p.receiverTopics, p.receiverOptions...)
+	default:
+		p.Consumer = NewConsumerFromClient(p.Client, p.receiverGroupId, p.receiverTopic, p.receiverOptions...)
+	}
+
+	if p.replyTopic != "" {
+		p.Requester, err = NewRequester(p.Client, p.Sender, p.replyTopic)
+		if err != nil {
+			_ = p.Consumer.Close(context.Background())
+			_ = p.Sender.Close(context.Background())
+			return nil, err
+		}
+	}
 
 	return p, nil
 }
@@ -99,8 +138,12 @@ func (p *Protocol) OpenInbound(ctx context.Context) error {
 	p.consumerMux.Lock()
 	defer p.consumerMux.Unlock()
 
-	logger := cecontext.LoggerFrom(ctx)
-	logger.Infof("Starting consumer group to topic %s and group id %s", p.receiverTopic, p.receiverGroupId)
+	logger := cecontext.StructuredLoggerFrom(ctx)
+	if p.receiverTopicPattern != nil {
+		logger.Infow("Starting consumer group", "topicPattern", p.receiverTopicPattern.String(), "groupId", p.receiverGroupId)
+	} else {
+		logger.Infow("Starting consumer group", "topics", p.Consumer.topics, "groupId", p.receiverGroupId)
+	}
 
 	return p.Consumer.OpenInbound(ctx)
 }
@@ -112,11 +155,70 @@ func (p *Protocol) Send(ctx context.Context, in binding.Message, transformers ..
 	return p.Sender.Send(ctx, in, transformers...)
 }
 
+// Request implements Requester.Request, see kafka_sarama.Requester.
+// The Protocol must be created with the WithReplyTopic option.
+func (p *Protocol) Request(ctx context.Context, in binding.Message, transformers ...binding.Transformer) (binding.Message, error) {
+	if p.Requester == nil {
+		return nil, errors.New("you didn't specify the reply topic, use kafka_sarama.WithReplyTopic")
+	}
+	for _, f := range p.SenderContextDecorators {
+		ctx = f(ctx)
+	}
+	return p.Requester.Request(ctx, in, transformers...)
+}
+
 func (p *Protocol) Receive(ctx context.Context) (binding.Message, error) {
 	return p.Consumer.Receive(ctx)
 }
 
+// Respond implements Responder.Respond.
+// The response is sent to the topic of the ReplyTopicHeader header of the
+// request, with the CorrelationIdHeader header of the request. The response to
+// a request without ReplyTopicHeader header is discarded.
+// The offset of the request is marked only if the response is sent.
+func (p *Protocol) Respond(ctx context.Context) (binding.Message, protocol.ResponseFn, error) {
+	m, err := p.Consumer.Receive(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var replyTopic, correlationId string
+	topic := ProtocolContextFrom(binding.WithProtocolContext(ctx, m)).Topic
+	if km, ok := binding.UnwrapMessage(m).(*Message); ok {
+		replyTopic = string(km.Headers[strings.ToLower(ReplyTopicHeader)])
+		correlationId = string(km.Headers[strings.ToLower(CorrelationIdHeader)])
+	}
+
+	return m, func(ctx context.Context, resp binding.Message, r protocol.Result, transformers ...binding.Transformer) error {
+		if resp == nil {
+			return r
+		}
+		if replyTopic == "" {
+			cecontext.StructuredLoggerFrom(ctx).Warnw("dropping the response of a request without reply topic", "topic", topic)
+			_ = resp.Finish(nil)
+			return r
+		}
+
+		ctx = cecontext.WithTopic(ctx, replyTopic)
+		if correlationId != "" {
+			ctx = WithMessageHeaders(ctx, sarama.RecordHeader{Key: []byte(CorrelationIdHeader), Value: []byte(correlationId)})
+		}
+		for _, f := range p.SenderContextDecorators {
+			ctx = f(ctx)
+		}
+		if err := p.Sender.Send(ctx, resp, transformers...); err != nil {
+			return protocol.NewReceipt(false, "failed to send the response to %q: %w", replyTopic, err)
+		}
+		return r
+	}, nil
+}
+
 func (p *Protocol) Close(ctx context.Context) error {
+	if p.Requester != nil {
+		if err := p.Requester.Close(ctx); err != nil {
+			return err
+		}
+	}
 	if p.ownsClient {
 		// Just closing the client here closes at cascade consumer and producer
 		return p.Client.Close()
@@ -127,7 +229,9 @@ func (p *Protocol) Close(ctx context.Context) error {
 	return p.Sender.Close(ctx)
 }
 
-// Kafka protocol implements Sender, Receiver
+// Kafka protocol implements Sender, Requester, Receiver, Responder
 var _ protocol.Sender = (*Protocol)(nil)
+var _ protocol.Requester = (*Protocol)(nil)
 var _ protocol.Receiver = (*Protocol)(nil)
+var _ protocol.Responder = (*Protocol)(nil)
 var _ protocol.Closer = (*Protocol)(nil)
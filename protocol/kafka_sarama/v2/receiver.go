@@ -3,14 +3,20 @@ package kafka_sarama
 import (
 	"context"
 	"io"
+	"regexp"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 )
 
+const defaultRefreshInterval = time.Minute
+
 type msgErr struct {
 	msg binding.Message
 	err error
@@ -18,28 +24,82 @@ type msgErr struct {
 
 // Receiver which implements sarama.ConsumerGroupHandler
 // After the first invocation of Receiver.Receive(), the sarama.ConsumerGroup is created and started.
+// By default the offset of a message is marked when the message is ACKed, while
+// the NACKed messages are skipped. See ReceiverOptionFunc to configure the
+// handling of the failures and of the offsets. When the failed messages can't be
+// sent to the retry or dead letter topics, they're sent again with exponential
+// backoff, and the partition is paused meanwhile: no further offsets are marked
+// nor messages received, and the partition is stopped until the next session if
+// the session ends first.
 type Receiver struct {
 	once     sync.Once
 	incoming chan msgErr
+
+	// Options
+	retryParams     *cecontext.RetryParams
+	retryTopics     []RetryTopic
+	deadLetterTopic string
+	failureSender   protocol.Sender
+	failurePeriod   time.Duration
+	manualCommit    bool
+	shutdownTimeout time.Duration
+	keyAttribute    string
+	onAssigned      PartitionsHookFunc
+	onRevoked       PartitionsHookFunc
+
+	// Assignment of the current session
+	partitionsMux sync.RWMutex
+	assignment    map[string][]int32
+	partitions    map[topicPartition]*partitionState
 }
 
 // NewReceiver creates a Receiver which implements sarama.ConsumerGroupHandler
 // The sarama.ConsumerGroup must be started invoking. If you need a Receiver which also manage the ConsumerGroup, use NewConsumer
 // After the first invocation of Receiver.Receive(), the sarama.ConsumerGroup is created and started.
-func NewReceiver() *Receiver {
-	return &Receiver{
+func NewReceiver(opts ...ReceiverOptionFunc) *Receiver {
+	r := &Receiver{
 		incoming: make(chan msgErr),
 	}
+	r.applyOptions(opts...)
+	return r
 }
 
-func (r *Receiver) Setup(sarama.ConsumerGroupSession) error {
-	return nil
+func (r *Receiver) applyOptions(opts ...ReceiverOptionFunc) {
+	for _, fn := range opts {
+		fn(r)
+	}
 }
 
-func (r *Receiver) Cleanup(sarama.ConsumerGroupSession) error {
+// Setup implements sarama.ConsumerGroupHandler.Setup, invoking the
+// WithPartitionsAssigned hook with the partitions assigned to the session.
+func (r *Receiver) Setup(session sarama.ConsumerGroupSession) error {
+	assignment := session.Claims()
+	r.partitionsMux.Lock()
+	r.assignment = assignment
+	r.partitions = make(map[topicPartition]*partitionState)
+	r.partitionsMux.Unlock()
+
+	if r.onAssigned != nil {
+		return r.onAssigned(session, copyAssignment(assignment))
+	}
 	return nil
 }
 
+// Cleanup implements sarama.ConsumerGroupHandler.Cleanup, invoking the
+// WithPartitionsRevoked hook with the partitions revoked from the session.
+func (r *Receiver) Cleanup(session sarama.ConsumerGroupSession) error {
+	var err error
+	if r.onRevoked != nil {
+		err = r.onRevoked(session, r.Assignment())
+	}
+
+	r.partitionsMux.Lock()
+	r.assignment = nil
+	r.partitions = nil
+	r.partitionsMux.Unlock()
+	return err
+}
+
 func (r *Receiver) Close(context.Context) error {
 	r.once.Do(func() {
 		close(r.incoming)
@@ -48,20 +108,108 @@ func (r *Receiver) Close(context.Context) error {
 }
 
 func (r *Receiver) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	ctx := session.Context()
+	var inFlight sync.WaitGroup
+	defer r.waitInFlight(&inFlight)
+
+	state := r.claimPartition(claim)
 	for message := range claim.Messages() {
-		m := NewMessageFromConsumerMessage(message)
+		message := message
+		state.consumed(message)
+		if !waitNotBefore(ctx, message) || !state.wait(ctx) {
+			return nil
+		}
 
-		r.incoming <- msgErr{
-			msg: binding.WithFinish(m, func(err error) {
-				if protocol.IsACK(err) {
-					session.MarkMessage(message, "")
-				}
-			}),
+		for tries := 0; ; tries++ {
+			results := make(chan error, 1)
+			inFlight.Add(1)
+			r.incoming <- msgErr{
+				msg: binding.WithFinish(r.newMessage(session, message), func(err error) {
+					defer inFlight.Done()
+					if r.retryParams == nil {
+						r.handleResult(ctx, session, state, message, err)
+					}
+					results <- err
+				}),
+			}
+			if r.retryParams == nil {
+				break
+			}
+
+			// Retry in place: wait the result before consuming the next message
+			var err error
+			select {
+			case err = <-results:
+			case <-ctx.Done():
+				return nil
+			}
+			if protocol.IsACK(err) || tries >= r.retryParams.MaxTries {
+				r.handleResult(ctx, session, state, message, err)
+				break
+			}
+			select {
+			case <-time.After(r.retryParams.BackoffFor(tries)):
+			case <-ctx.Done():
+				return nil
+			}
 		}
 	}
 	return nil
 }
 
+func (r *Receiver) newMessage(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage) *Message {
+	m := NewMessageFromConsumerMessageWithKeyAttribute(message, r.keyAttribute)
+	m.protocolContext.commit = func() {
+		session.MarkMessage(message, "")
+	}
+	return m
+}
+
+// handleResult marks the offset of the ACKed message, unless the commit is
+// manual, and handles the failure of the NACKed message. While the failed
+// message can't be sent to the retry or dead letter topics, the partition is
+// paused, as marking the offsets of the following messages would lose it.
+func (r *Receiver) handleResult(ctx context.Context, session sarama.ConsumerGroupSession, state *partitionState, message *sarama.ConsumerMessage, result error) {
+	if protocol.IsACK(result) {
+		if !r.manualCommit && state.canMark() {
+			session.MarkMessage(message, "")
+		}
+		return
+	}
+	handled, err := r.handleFailure(ctx, message, result)
+	if err != nil {
+		state.pause()
+		handled, err = r.retryFailure(ctx, message, result, err)
+		state.resume(err == nil)
+		if err != nil {
+			cecontext.StructuredLoggerFrom(ctx).Errorw("failed to handle the failure of the message, the partition is stopped until the next session",
+				"topic", message.Topic, "partition", message.Partition, "offset", message.Offset, "error", err)
+			return
+		}
+	}
+	if (handled || r.retryParams != nil) && state.canMark() {
+		// With retries in place, the message is skipped when the retries are exhausted
+		session.MarkMessage(message, "")
+	}
+}
+
+// waitInFlight waits the finish of the messages in flight, up to the shutdown
+// timeout, so that their offsets are committed when the session ends.
+func (r *Receiver) waitInFlight(inFlight *sync.WaitGroup) {
+	if r.shutdownTimeout <= 0 {
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(r.shutdownTimeout):
+	}
+}
+
 func (r *Receiver) Receive(ctx context.Context) (binding.Message, error) {
 	select {
 	case <-ctx.Done():
@@ -83,34 +231,56 @@ type Consumer struct {
 	client    sarama.Client
 	ownClient bool
 
-	topic   string
-	groupId string
+	topics          []string
+	topicPattern    *regexp.Regexp
+	refreshInterval time.Duration
+	groupId         string
 
 	cgMtx sync.Mutex
 }
 
-func NewConsumer(brokers []string, saramaConfig *sarama.Config, groupId string, topic string) (*Consumer, error) {
+func NewConsumer(brokers []string, saramaConfig *sarama.Config, groupId string, topic string, opts ...ReceiverOptionFunc) (*Consumer, error) {
 	client, err := sarama.NewClient(brokers, saramaConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	consumer := NewConsumerFromClient(client, groupId, topic)
+	consumer := NewConsumerFromClient(client, groupId, topic, opts...)
 	consumer.ownClient = true
 
 	return consumer, nil
 }
 
-func NewConsumerFromClient(client sarama.Client, groupId string, topic string) *Consumer {
-	return &Consumer{
+func NewConsumerFromClient(client sarama.Client, groupId string, topic string, opts ...ReceiverOptionFunc) *Consumer {
+	return NewMultiTopicConsumerFromClient(client, groupId, []string{topic}, opts...)
+}
+
+// NewMultiTopicConsumerFromClient returns a Consumer subscribing to all the topics.
+func NewMultiTopicConsumerFromClient(client sarama.Client, groupId string, topics []string, opts ...ReceiverOptionFunc) *Consumer {
+	c := &Consumer{
 		Receiver: Receiver{
 			incoming: make(chan msgErr),
 		},
 		client:    client,
-		topic:     topic,
+		topics:    topics,
 		groupId:   groupId,
 		ownClient: false,
 	}
+	c.Receiver.applyOptions(opts...)
+	return c
+}
+
+// NewPatternConsumerFromClient returns a Consumer subscribing to the topics
+// matching pattern. The topics are refreshed every refreshInterval, default is
+// one minute: when they change, the consumer group session is restarted.
+func NewPatternConsumerFromClient(client sarama.Client, groupId string, pattern *regexp.Regexp, refreshInterval time.Duration, opts ...ReceiverOptionFunc) *Consumer {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultRefreshInterval
+	}
+	c := NewMultiTopicConsumerFromClient(client, groupId, nil, opts...)
+	c.topicPattern = pattern
+	c.refreshInterval = refreshInterval
+	return c
 }
 
 func (c *Consumer) OpenInbound(ctx context.Context) error {
@@ -147,7 +317,23 @@ func (c *Consumer) startConsumerGroupLoop(cg sarama.ConsumerGroup, ctx context.C
 	// Need to be wrapped in a for loop
 	// https://godoc.org/github.com/Shopify/sarama#ConsumerGroup
 	for {
-		err := cg.Consume(context.Background(), []string{c.topic}, c)
+		topics, err := c.subscribedTopics()
+		if err == nil {
+			// The session is restarted when the topics matching the pattern change
+			consumeCtx, cancel := context.WithCancel(context.Background())
+			if c.topicPattern != nil {
+				go c.watchTopics(consumeCtx, cancel, topics)
+			}
+			if len(topics) > 0 {
+				err = cg.Consume(consumeCtx, topics, c)
+			} else {
+				select {
+				case <-consumeCtx.Done():
+				case <-ctx.Done():
+				}
+			}
+			cancel()
+		}
 
 		select {
 		// If context is closed, then consumer group session was closed by the user
@@ -172,6 +358,64 @@ func (c *Consumer) startConsumerGroupLoop(cg sarama.ConsumerGroup, ctx context.C
 	}
 }
 
+// subscribedTopics returns the topics of the consumer, or the topics matching
+// its pattern.
+func (c *Consumer) subscribedTopics() ([]string, error) {
+	if c.topicPattern == nil {
+		return c.topics, nil
+	}
+	if err := c.client.RefreshMetadata(); err != nil {
+		return nil, err
+	}
+	all, err := c.client.Topics()
+	if err != nil {
+		return nil, err
+	}
+	topics := make([]string, 0, len(all))
+	for _, topic := range all {
+		if c.topicPattern.MatchString(topic) {
+			topics = append(topics, topic)
+		}
+	}
+	sort.Strings(topics)
+	return topics, nil
+}
+
+// watchTopics invokes cancel when the topics matching the pattern differ from
+// topics, or ctx is done.
+func (c *Consumer) watchTopics(ctx context.Context, cancel context.CancelFunc, topics []string) {
+	ticker := time.NewTicker(c.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current, err := c.subscribedTopics()
+			if err != nil {
+				cecontext.StructuredLoggerFrom(ctx).Warnw("failed to refresh the topics", "pattern", c.topicPattern.String(), "error", err)
+				continue
+			}
+			if !equalTopics(topics, current) {
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+func equalTopics(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *Consumer) Close(ctx context.Context) error {
 	if c.ownClient {
 		return c.client.Close()
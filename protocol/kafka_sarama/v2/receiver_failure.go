@@ -0,0 +1,149 @@
+package kafka_sarama
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+)
+
+const (
+	// RetryAttemptHeader is the header holding the number of retry topics the
+	// message went through
+	RetryAttemptHeader = "kafka_retryAttempt"
+	// RetryNotBeforeHeader is the header holding the time, in milliseconds
+	// since the Unix epoch, before which the message must not be received
+	RetryNotBeforeHeader = "kafka_retryNotBefore"
+	// OriginalTopicHeader is the header holding the topic the failed message
+	// was first received from
+	OriginalTopicHeader = "kafka_originalTopic"
+	// OriginalPartitionHeader is the header holding the partition the failed
+	// message was first received from
+	OriginalPartitionHeader = "kafka_originalPartition"
+	// OriginalOffsetHeader is the header holding the offset of the failed
+	// message in the partition it was first received from
+	OriginalOffsetHeader = "kafka_originalOffset"
+	// FailureHeader is the header holding the last error of the failed message
+	FailureHeader = "kafka_failure"
+)
+
+const (
+	defaultFailurePeriod = 100 * time.Millisecond
+	maxFailureBackoff    = 30 * time.Second
+)
+
+// RetryTopic is a topic of the retry topics chain, see WithRetryTopics.
+type RetryTopic struct {
+	Topic string
+	// Delay is the time waited before receiving the message published to Topic
+	Delay time.Duration
+}
+
+// handleFailure publishes the message to the next retry topic or to the dead
+// letter topic. handled is false when no topic is configured.
+func (r *Receiver) handleFailure(ctx context.Context, message *sarama.ConsumerMessage, result error) (handled bool, err error) {
+	if r.failureSender == nil {
+		return false, nil
+	}
+
+	attempt, _ := strconv.Atoi(header(message.Headers, RetryAttemptHeader))
+	headers := originalHeaders(message)
+	if result != nil {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(FailureHeader), Value: []byte(result.Error())})
+	}
+
+	var topic string
+	switch {
+	case attempt < len(r.retryTopics):
+		retryTopic := r.retryTopics[attempt]
+		topic = retryTopic.Topic
+		notBefore := time.Now().Add(retryTopic.Delay).UnixNano() / int64(time.Millisecond)
+		headers = append(headers,
+			sarama.RecordHeader{Key: []byte(RetryAttemptHeader), Value: []byte(strconv.Itoa(attempt + 1))},
+			sarama.RecordHeader{Key: []byte(RetryNotBeforeHeader), Value: []byte(strconv.FormatInt(notBefore, 10))},
+		)
+	case r.deadLetterTopic != "":
+		topic = r.deadLetterTopic
+	default:
+		return false, nil
+	}
+
+	ctx = cecontext.WithTopic(ctx, topic)
+	ctx = WithMessageHeaders(ctx, headers...)
+	if len(message.Key) > 0 {
+		ctx = WithMessageKey(ctx, sarama.ByteEncoder(message.Key))
+	}
+	// The message is not wrapped, so the sender doesn't finish the received message
+	if err := r.failureSender.Send(ctx, NewMessageFromConsumerMessage(message)); err != nil {
+		return false, fmt.Errorf("failed to send the message to %q: %w", topic, err)
+	}
+	return true, nil
+}
+
+// retryFailure sends again the failed message with handleFailure, after the
+// sending failed with err, with exponential backoff until it succeeds or ctx is
+// done.
+func (r *Receiver) retryFailure(ctx context.Context, message *sarama.ConsumerMessage, result error, err error) (handled bool, _ error) {
+	backoff := r.failurePeriod
+	if backoff <= 0 {
+		backoff = defaultFailurePeriod
+	}
+	for err != nil {
+		cecontext.StructuredLoggerFrom(ctx).Warnw("failed to handle the failure of the message, retrying",
+			"topic", message.Topic, "partition", message.Partition, "offset", message.Offset, "backoff", backoff, "error", err)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return false, err
+		}
+		if backoff *= 2; backoff > maxFailureBackoff {
+			backoff = maxFailureBackoff
+		}
+		handled, err = r.handleFailure(ctx, message, result)
+	}
+	return handled, nil
+}
+
+// originalHeaders returns the headers locating the message in the topic it was
+// first received from.
+func originalHeaders(message *sarama.ConsumerMessage) []sarama.RecordHeader {
+	if topic := header(message.Headers, OriginalTopicHeader); topic != "" {
+		return []sarama.RecordHeader{
+			{Key: []byte(OriginalTopicHeader), Value: []byte(topic)},
+			{Key: []byte(OriginalPartitionHeader), Value: []byte(header(message.Headers, OriginalPartitionHeader))},
+			{Key: []byte(OriginalOffsetHeader), Value: []byte(header(message.Headers, OriginalOffsetHeader))},
+		}
+	}
+	return []sarama.RecordHeader{
+		{Key: []byte(OriginalTopicHeader), Value: []byte(message.Topic)},
+		{Key: []byte(OriginalPartitionHeader), Value: []byte(strconv.FormatInt(int64(message.Partition), 10))},
+		{Key: []byte(OriginalOffsetHeader), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+	}
+}
+
+// waitNotBefore pauses the consumption of the partition until the time of the
+// RetryNotBeforeHeader header of the message. It returns false if ctx is done.
+func waitNotBefore(ctx context.Context, message *sarama.ConsumerMessage) bool {
+	notBefore, err := strconv.ParseInt(header(message.Headers, RetryNotBeforeHeader), 10, 64)
+	if err != nil {
+		return true
+	}
+	delay := time.Until(time.Unix(0, notBefore*int64(time.Millisecond)))
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
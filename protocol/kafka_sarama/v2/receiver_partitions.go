@@ -0,0 +1,148 @@
+package kafka_sarama
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Shopify/sarama"
+)
+
+// PartitionsHookFunc is invoked with the partitions, by topic, assigned to or
+// revoked from the consumer group session. A hook can mark the offsets with the
+// session, e.g. flushing the per-partition state on revocation.
+// An error ends the session.
+type PartitionsHookFunc func(session sarama.ConsumerGroupSession, partitions map[string][]int32) error
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+// partitionState tracks the consumption of a claimed partition.
+type partitionState struct {
+	claim sarama.ConsumerGroupClaim
+	// next is the offset of the next message, or a negative value if unknown
+	next int64
+
+	// The partition is paused while failed messages can't be sent to the retry
+	// or dead letter topics, and stopped if they're never sent
+	pauseMux sync.Mutex
+	paused   int
+	resumed  chan struct{}
+	stopped  bool
+}
+
+func (s *partitionState) consumed(message *sarama.ConsumerMessage) {
+	atomic.StoreInt64(&s.next, message.Offset+1)
+}
+
+// pause stops marking the offsets of the partition and consuming it, until
+// resume is called.
+func (s *partitionState) pause() {
+	s.pauseMux.Lock()
+	defer s.pauseMux.Unlock()
+	if s.paused == 0 {
+		s.resumed = make(chan struct{})
+	}
+	s.paused++
+}
+
+// resume resumes the partition paused by pause, or stops it for the rest of the
+// session if the failed message was not sent, so that it's received again by
+// the next session.
+func (s *partitionState) resume(sent bool) {
+	s.pauseMux.Lock()
+	defer s.pauseMux.Unlock()
+	if !sent {
+		s.stopped = true
+	}
+	s.paused--
+	if s.paused == 0 {
+		close(s.resumed)
+	}
+}
+
+// canMark returns whether the offsets of the partition can be marked.
+func (s *partitionState) canMark() bool {
+	s.pauseMux.Lock()
+	defer s.pauseMux.Unlock()
+	return s.paused == 0 && !s.stopped
+}
+
+// wait waits until the partition is not paused. It returns false if the
+// partition is stopped or ctx is done.
+func (s *partitionState) wait(ctx context.Context) bool {
+	s.pauseMux.Lock()
+	resumed, stopped := s.resumed, s.stopped
+	if s.paused == 0 {
+		resumed = nil
+	}
+	s.pauseMux.Unlock()
+	if stopped {
+		return false
+	}
+	if resumed != nil {
+		select {
+		case <-resumed:
+		case <-ctx.Done():
+			return false
+		}
+		return s.wait(ctx)
+	}
+	return true
+}
+
+func (r *Receiver) claimPartition(claim sarama.ConsumerGroupClaim) *partitionState {
+	state := &partitionState{claim: claim, next: claim.InitialOffset()}
+	r.partitionsMux.Lock()
+	defer r.partitionsMux.Unlock()
+	if r.partitions != nil {
+		r.partitions[topicPartition{topic: claim.Topic(), partition: claim.Partition()}] = state
+	}
+	return state
+}
+
+// Assignment returns the partitions, by topic, assigned to the current consumer
+// group session, or nil if there is no session.
+func (r *Receiver) Assignment() map[string][]int32 {
+	r.partitionsMux.RLock()
+	defer r.partitionsMux.RUnlock()
+	return copyAssignment(r.assignment)
+}
+
+// Lag returns the number of messages, by topic and partition, not yet
+// consumed from the partitions of the current consumer group session.
+// Partitions whose position is not known yet, e.g. consuming from the newest
+// offset before the first message, are not included.
+func (r *Receiver) Lag() map[string]map[int32]int64 {
+	r.partitionsMux.RLock()
+	defer r.partitionsMux.RUnlock()
+	lag := make(map[string]map[int32]int64)
+	for tp, state := range r.partitions {
+		next := atomic.LoadInt64(&state.next)
+		if next < 0 {
+			continue
+		}
+		if lag[tp.topic] == nil {
+			lag[tp.topic] = make(map[int32]int64)
+		}
+		if l := state.claim.HighWaterMarkOffset() - next; l > 0 {
+			lag[tp.topic][tp.partition] = l
+		} else {
+			lag[tp.topic][tp.partition] = 0
+		}
+	}
+	return lag
+}
+
+func copyAssignment(assignment map[string][]int32) map[string][]int32 {
+	if assignment == nil {
+		return nil
+	}
+	c := make(map[string][]int32, len(assignment))
+	for topic, partitions := range assignment {
+		c[topic] = append([]int32(nil), partitions...)
+	}
+	return c
+}
@@ -0,0 +1,382 @@
+package kafka_sarama
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+type sessionMock struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	claims map[string][]int32
+	lock   sync.Mutex
+	marked []int64
+}
+
+func (s *sessionMock) Claims() map[string][]int32 { return s.claims }
+func (s *sessionMock) MemberID() string           { return "" }
+func (s *sessionMock) GenerationID() int32        { return 0 }
+func (s *sessionMock) MarkOffset(topic string, partition int32, offset int64, metadata string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.marked = append(s.marked, offset)
+}
+func (s *sessionMock) ResetOffset(topic string, partition int32, offset int64, metadata string) {}
+func (s *sessionMock) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.MarkOffset(msg.Topic, msg.Partition, msg.Offset+1, metadata)
+}
+func (s *sessionMock) Context() context.Context { return s.ctx }
+
+func (s *sessionMock) markedOffsets() []int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]int64(nil), s.marked...)
+}
+
+type claimMock struct {
+	messages chan *sarama.ConsumerMessage
+	hwm      int64
+}
+
+func (c *claimMock) Topic() string                            { return "aaa" }
+func (c *claimMock) Partition() int32                         { return 0 }
+func (c *claimMock) InitialOffset() int64                     { return 0 }
+func (c *claimMock) HighWaterMarkOffset() int64               { return c.hwm }
+func (c *claimMock) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+// consumeClaim runs r.ConsumeClaim with the messages, returning the session
+// and a channel closed when ConsumeClaim returns.
+func consumeClaim(t *testing.T, r *Receiver, messages ...*sarama.ConsumerMessage) (*sessionMock, <-chan struct{}) {
+	session := &sessionMock{ctx: context.TODO()}
+	claim := &claimMock{messages: make(chan *sarama.ConsumerMessage, len(messages))}
+	for _, m := range messages {
+		claim.messages <- m
+	}
+	close(claim.messages)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, r.ConsumeClaim(session, claim))
+	}()
+	return session, done
+}
+
+func consumerMessage(offset int64, headers ...*sarama.RecordHeader) *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Topic:     "aaa",
+		Offset:    offset,
+		Key:       []byte("key"),
+		Value:     []byte("hello"),
+		Headers:   append([]*sarama.RecordHeader{{Key: []byte("ce_specversion"), Value: []byte("1.0")}}, headers...),
+		Timestamp: time.Now(),
+	}
+}
+
+func receiveAndFinish(t *testing.T, r *Receiver, results ...error) {
+	for _, result := range results {
+		m, err := r.Receive(context.TODO())
+		require.NoError(t, err)
+		require.NoError(t, m.Finish(result))
+	}
+}
+
+func TestReceiver_MarkOnACK(t *testing.T) {
+	r := NewReceiver()
+	session, done := consumeClaim(t, r, consumerMessage(0), consumerMessage(1))
+
+	receiveAndFinish(t, r, protocol.ResultNACK, protocol.ResultACK)
+	<-done
+	require.Equal(t, []int64{2}, session.markedOffsets())
+}
+
+func TestReceiver_KeyAttribute(t *testing.T) {
+	for name, tc := range map[string]struct {
+		options []ReceiverOptionFunc
+		want    interface{}
+	}{
+		"default":       {},
+		"key attribute": {options: []ReceiverOptionFunc{WithKeyAttribute("partitionkey")}, want: "key"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := NewReceiver(tc.options...)
+			_, done := consumeClaim(t, r, consumerMessage(0))
+
+			m, err := r.Receive(context.TODO())
+			require.NoError(t, err)
+			e, err := binding.ToEvent(context.TODO(), m)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, e.Extensions()["partitionkey"])
+			require.NoError(t, m.Finish(nil))
+			<-done
+		})
+	}
+}
+
+func TestReceiver_RetryInPlace(t *testing.T) {
+	failureSender := &Sender{topic: "aaa", syncProducer: &syncProducerMock{}}
+	r := NewReceiver(
+		WithRetryInPlace(cecontext.RetryParams{Strategy: cecontext.BackoffStrategyConstant, MaxTries: 2, Period: time.Millisecond}),
+		WithDeadLetterTopic(failureSender, "dlq"),
+	)
+	session, done := consumeClaim(t, r, consumerMessage(0), consumerMessage(1))
+
+	// The first message is ACKed at the second retry
+	receiveAndFinish(t, r, protocol.ResultNACK, protocol.ResultNACK, protocol.ResultACK)
+	// The retries of the second message are exhausted
+	receiveAndFinish(t, r, protocol.ResultNACK, protocol.ResultNACK, errors.New("boom"))
+	<-done
+
+	require.Equal(t, []int64{1, 2}, session.markedOffsets())
+	sent := failureSender.syncProducer.(*syncProducerMock).sent
+	require.Len(t, sent, 1)
+	require.Equal(t, "dlq", sent[0].Topic)
+	key, err := sent[0].Key.Encode()
+	require.NoError(t, err)
+	require.Equal(t, []byte("key"), key)
+	headers := producerHeaders(sent[0])
+	require.Equal(t, "aaa", header(headers, OriginalTopicHeader))
+	require.Equal(t, "1", header(headers, OriginalOffsetHeader))
+	require.Equal(t, "boom", header(headers, FailureHeader))
+}
+
+func TestReceiver_RetryTopics(t *testing.T) {
+	failureSender := &Sender{topic: "aaa", syncProducer: &syncProducerMock{}}
+	r := NewReceiver(
+		WithRetryTopics(failureSender, RetryTopic{Topic: "retry-1", Delay: time.Minute}),
+		WithDeadLetterTopic(failureSender, "dlq"),
+	)
+	start := time.Now()
+	notBefore := time.Now().Add(20*time.Millisecond).UnixNano() / int64(time.Millisecond)
+	session, done := consumeClaim(t, r,
+		consumerMessage(0),
+		// A message from the retry topic
+		consumerMessage(1,
+			&sarama.RecordHeader{Key: []byte(RetryAttemptHeader), Value: []byte("1")},
+			&sarama.RecordHeader{Key: []byte(RetryNotBeforeHeader), Value: []byte(strconv.FormatInt(notBefore, 10))},
+			&sarama.RecordHeader{Key: []byte(OriginalTopicHeader), Value: []byte("original")},
+			&sarama.RecordHeader{Key: []byte(OriginalPartitionHeader), Value: []byte("3")},
+			&sarama.RecordHeader{Key: []byte(OriginalOffsetHeader), Value: []byte("42")},
+		),
+	)
+
+	receiveAndFinish(t, r, protocol.ResultNACK, protocol.ResultNACK)
+	<-done
+	require.True(t, time.Since(start) >= 10*time.Millisecond)
+
+	require.Equal(t, []int64{1, 2}, session.markedOffsets())
+	sent := failureSender.syncProducer.(*syncProducerMock).sent
+	require.Len(t, sent, 2)
+
+	require.Equal(t, "retry-1", sent[0].Topic)
+	headers := producerHeaders(sent[0])
+	require.Equal(t, "1", header(headers, RetryAttemptHeader))
+	require.NotEmpty(t, header(headers, RetryNotBeforeHeader))
+	require.Equal(t, "aaa", header(headers, OriginalTopicHeader))
+	require.Equal(t, "0", header(headers, OriginalOffsetHeader))
+
+	require.Equal(t, "dlq", sent[1].Topic)
+	headers = producerHeaders(sent[1])
+	require.Equal(t, "original", header(headers, OriginalTopicHeader))
+	require.Equal(t, "3", header(headers, OriginalPartitionHeader))
+	require.Equal(t, "42", header(headers, OriginalOffsetHeader))
+}
+
+// failingSender fails the first failures sends, signaling them on failed.
+type failingSender struct {
+	protocol.Sender
+	failures int32
+	failed   chan struct{}
+}
+
+func (s *failingSender) Send(ctx context.Context, m binding.Message, transformers ...binding.Transformer) error {
+	if atomic.AddInt32(&s.failures, -1) >= 0 {
+		select {
+		case s.failed <- struct{}{}:
+		default:
+		}
+		return errors.New("unavailable")
+	}
+	return s.Sender.Send(ctx, m, transformers...)
+}
+
+func TestReceiver_DeadLetterTopicRetry(t *testing.T) {
+	producer := &syncProducerMock{}
+	failureSender := &failingSender{Sender: &Sender{topic: "aaa", syncProducer: producer}, failures: 2}
+	r := NewReceiver(WithDeadLetterTopic(failureSender, "dlq"))
+	r.failurePeriod = time.Millisecond
+	session, done := consumeClaim(t, r, consumerMessage(0), consumerMessage(1))
+
+	// The message is sent at the third try, before the next one is received
+	receiveAndFinish(t, r, protocol.ResultNACK, protocol.ResultACK)
+	<-done
+
+	require.Equal(t, []int64{1, 2}, session.markedOffsets())
+	require.Len(t, producer.sent, 1)
+	require.Equal(t, "dlq", producer.sent[0].Topic)
+}
+
+func TestReceiver_DeadLetterTopicUnavailable(t *testing.T) {
+	failureSender := &failingSender{Sender: &Sender{topic: "aaa", syncProducer: &syncProducerMock{}}, failures: math.MaxInt32, failed: make(chan struct{})}
+	r := NewReceiver(WithDeadLetterTopic(failureSender, "dlq"))
+	r.failurePeriod = time.Millisecond
+	ctx, cancel := context.WithCancel(context.TODO())
+	session := &sessionMock{ctx: ctx}
+	claim := &claimMock{messages: make(chan *sarama.ConsumerMessage, 2)}
+	claim.messages <- consumerMessage(0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, r.ConsumeClaim(session, claim))
+	}()
+
+	m, err := r.Receive(context.TODO())
+	require.NoError(t, err)
+	finished := make(chan error)
+	go func() {
+		finished <- m.Finish(protocol.ResultNACK)
+	}()
+	<-failureSender.failed
+
+	// The partition is paused while the failed message is not sent
+	claim.messages <- consumerMessage(1)
+	close(claim.messages)
+	receiveCtx, receiveCancel := context.WithTimeout(context.TODO(), 20*time.Millisecond)
+	defer receiveCancel()
+	_, err = r.Receive(receiveCtx)
+	require.Equal(t, io.EOF, err)
+
+	// The session ends without marking the failed message
+	cancel()
+	require.NoError(t, <-finished)
+	<-done
+	require.Empty(t, session.markedOffsets())
+}
+
+func TestReceiver_ManualCommit(t *testing.T) {
+	r := NewReceiver(WithManualCommit())
+	session, done := consumeClaim(t, r, consumerMessage(0), consumerMessage(1))
+
+	receiveAndFinish(t, r, protocol.ResultACK)
+	m, err := r.Receive(context.TODO())
+	require.NoError(t, err)
+	require.NoError(t, CommitMessage(binding.WithProtocolContext(context.TODO(), m)))
+	require.NoError(t, m.Finish(protocol.ResultACK))
+	<-done
+
+	require.Equal(t, []int64{2}, session.markedOffsets())
+	require.Error(t, CommitMessage(context.TODO()))
+}
+
+func TestReceiver_CommitOnShutdown(t *testing.T) {
+	r := NewReceiver(WithCommitOnShutdown(time.Second))
+	session, done := consumeClaim(t, r, consumerMessage(0))
+
+	m, err := r.Receive(context.TODO())
+	require.NoError(t, err)
+	select {
+	case <-done:
+		require.Fail(t, "ConsumeClaim returned with a message in flight")
+	case <-time.After(10 * time.Millisecond):
+	}
+	require.NoError(t, m.Finish(protocol.ResultACK))
+	<-done
+	require.Equal(t, []int64{1}, session.markedOffsets())
+}
+
+func TestReceiver_PartitionsHooks(t *testing.T) {
+	var assigned, revoked map[string][]int32
+	r := NewReceiver(
+		WithPartitionsAssigned(func(session sarama.ConsumerGroupSession, partitions map[string][]int32) error {
+			assigned = partitions
+			return nil
+		}),
+		WithPartitionsRevoked(func(session sarama.ConsumerGroupSession, partitions map[string][]int32) error {
+			revoked = partitions
+			// Flush the state of the revoked partitions
+			session.MarkOffset("aaa", 0, 10, "")
+			return nil
+		}),
+	)
+	session := &sessionMock{ctx: context.TODO(), claims: map[string][]int32{"aaa": {0}}}
+	require.NoError(t, r.Setup(session))
+	require.Equal(t, map[string][]int32{"aaa": {0}}, assigned)
+	require.Equal(t, map[string][]int32{"aaa": {0}}, r.Assignment())
+
+	claim := &claimMock{messages: make(chan *sarama.ConsumerMessage, 1), hwm: 10}
+	claim.messages <- consumerMessage(3)
+	close(claim.messages)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, r.ConsumeClaim(session, claim))
+	}()
+	receiveAndFinish(t, r, protocol.ResultACK)
+	<-done
+	require.Equal(t, map[string]map[int32]int64{"aaa": {0: 6}}, r.Lag())
+
+	require.NoError(t, r.Cleanup(session))
+	require.Equal(t, map[string][]int32{"aaa": {0}}, revoked)
+	require.Nil(t, r.Assignment())
+	require.Empty(t, r.Lag())
+	require.Equal(t, []int64{4, 10}, session.markedOffsets())
+}
+
+func producerHeaders(pm *sarama.ProducerMessage) []*sarama.RecordHeader {
+	headers := make([]*sarama.RecordHeader, 0, len(pm.Headers))
+	for i := range pm.Headers {
+		headers = append(headers, &pm.Headers[i])
+	}
+	return headers
+}
+
+type clientMock struct {
+	sarama.Client
+	topics []string
+}
+
+func (c *clientMock) RefreshMetadata(...string) error { return nil }
+func (c *clientMock) Topics() ([]string, error)       { return c.topics, nil }
+
+func TestConsumer_SubscribedTopics(t *testing.T) {
+	client := &clientMock{topics: []string{"orders-b", "payments", "orders-a"}}
+
+	c := NewMultiTopicConsumerFromClient(client, "group", []string{"orders-a", "payments"})
+	topics, err := c.subscribedTopics()
+	require.NoError(t, err)
+	require.Equal(t, []string{"orders-a", "payments"}, topics)
+
+	c = NewPatternConsumerFromClient(client, "group", regexp.MustCompile("^orders-"), 0)
+	require.Equal(t, defaultRefreshInterval, c.refreshInterval)
+	topics, err = c.subscribedTopics()
+	require.NoError(t, err)
+	require.Equal(t, []string{"orders-a", "orders-b"}, topics)
+}
+
+func TestConsumer_WatchTopics(t *testing.T) {
+	client := &clientMock{topics: []string{"orders-a"}}
+	c := NewPatternConsumerFromClient(client, "group", regexp.MustCompile("^orders-"), time.Millisecond)
+	topics, err := c.subscribedTopics()
+	require.NoError(t, err)
+
+	client.topics = []string{"orders-a", "orders-b"}
+	ctx, cancel := context.WithCancel(context.TODO())
+	c.watchTopics(ctx, cancel, topics)
+	// The topics changed, so the context is cancelled
+	require.Error(t, ctx.Err())
+}
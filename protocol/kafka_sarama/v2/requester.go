@@ -0,0 +1,187 @@
+package kafka_sarama
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"github.com/google/uuid"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+const (
+	// ReplyTopicHeader is the header holding the topic where the response to a
+	// request must be sent
+	ReplyTopicHeader = "kafka_replyTopic"
+	// CorrelationIdHeader is the header correlating a response to its request
+	CorrelationIdHeader = "kafka_correlationId"
+)
+
+var errRequesterClosed = errors.New("requester closed")
+
+// Requester implements protocol.Requester, sending the requests with a Sender
+// and consuming the responses from a reply topic.
+// The requests carry the ReplyTopicHeader and the CorrelationIdHeader headers:
+// the responses are routed to the pending Request invocations using the
+// CorrelationIdHeader header. Responses not matching a pending request, e.g.
+// responses to the requests of other Requesters sharing the same reply topic,
+// are discarded.
+type Requester struct {
+	sender     *Sender
+	replyTopic string
+
+	consumer           sarama.Consumer
+	partitionConsumers []sarama.PartitionConsumer
+
+	pendingMux sync.Mutex
+	pending    map[string]chan *Message
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// NewRequester returns a Requester sending the requests with sender and
+// consuming the responses from all the partitions of replyTopic.
+// The consumption starts from the newest offset when the Requester is created.
+func NewRequester(client sarama.Client, sender *Sender, replyTopic string) (*Requester, error) {
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return nil, err
+	}
+	r, err := newRequester(consumer, sender, replyTopic)
+	if err != nil {
+		_ = consumer.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func newRequester(consumer sarama.Consumer, sender *Sender, replyTopic string) (*Requester, error) {
+	r := &Requester{
+		sender:     sender,
+		replyTopic: replyTopic,
+		consumer:   consumer,
+		pending:    make(map[string]chan *Message),
+		done:       make(chan struct{}),
+	}
+
+	partitions, err := consumer.Partitions(replyTopic)
+	if err != nil {
+		return nil, err
+	}
+	for _, partition := range partitions {
+		pc, err := consumer.ConsumePartition(replyTopic, partition, sarama.OffsetNewest)
+		if err != nil {
+			_ = r.closePartitionConsumers()
+			return nil, err
+		}
+		r.partitionConsumers = append(r.partitionConsumers, pc)
+	}
+	for _, pc := range r.partitionConsumers {
+		go r.consume(pc)
+		go consumeErrors(pc)
+	}
+	return r, nil
+}
+
+func (r *Requester) consume(pc sarama.PartitionConsumer) {
+	for cm := range pc.Messages() {
+		correlationId := header(cm.Headers, CorrelationIdHeader)
+		if correlationId == "" {
+			continue
+		}
+		r.pendingMux.Lock()
+		ch, ok := r.pending[correlationId]
+		delete(r.pending, correlationId)
+		r.pendingMux.Unlock()
+		if ok {
+			ch <- NewMessageFromConsumerMessage(cm)
+		}
+	}
+}
+
+// consumeErrors logs the errors of pc, returned when the
+// Consumer.Return.Errors option of the client config is set.
+func consumeErrors(pc sarama.PartitionConsumer) {
+	for err := range pc.Errors() {
+		cecontext.StructuredLoggerFrom(context.Background()).Warnw("failed to consume the reply topic",
+			"topic", err.Topic, "partition", err.Partition, "error", err.Err)
+	}
+}
+
+// Request implements protocol.Requester.Request, sending in to the topic of the
+// sender, or to the topic of ctx set with cecontext.WithTopic, and waiting the
+// response until ctx is done.
+func (r *Requester) Request(ctx context.Context, in binding.Message, transformers ...binding.Transformer) (binding.Message, error) {
+	correlationId := uuid.New().String()
+	ch := make(chan *Message, 1)
+
+	r.pendingMux.Lock()
+	r.pending[correlationId] = ch
+	r.pendingMux.Unlock()
+	defer func() {
+		r.pendingMux.Lock()
+		delete(r.pending, correlationId)
+		r.pendingMux.Unlock()
+	}()
+
+	ctx = WithMessageHeaders(ctx,
+		sarama.RecordHeader{Key: []byte(ReplyTopicHeader), Value: []byte(r.replyTopic)},
+		sarama.RecordHeader{Key: []byte(CorrelationIdHeader), Value: []byte(correlationId)},
+	)
+	if err := r.sender.Send(ctx, in, transformers...); err != nil {
+		return nil, err
+	}
+
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-r.done:
+		return nil, errRequesterClosed
+	case <-ctx.Done():
+		return nil, fmt.Errorf("no response with correlation id %q: %w", correlationId, ctx.Err())
+	}
+}
+
+// Close stops consuming the reply topic. The pending Request invocations fail.
+func (r *Requester) Close(context.Context) error {
+	var err error
+	r.closeOnce.Do(func() {
+		close(r.done)
+		err = r.closePartitionConsumers()
+		if err2 := r.consumer.Close(); err == nil {
+			err = err2
+		}
+	})
+	return err
+}
+
+func (r *Requester) closePartitionConsumers() error {
+	var err error
+	for _, pc := range r.partitionConsumers {
+		if err2 := pc.Close(); err == nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// header returns the value of the header with the provided key, ignoring the
+// case, or an empty string.
+func header(headers []*sarama.RecordHeader, key string) string {
+	for _, h := range headers {
+		if strings.EqualFold(string(h.Key), key) {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+var _ protocol.Requester = (*Requester)(nil)
+var _ protocol.Closer = (*Requester)(nil)
@@ -0,0 +1,177 @@
+package kafka_sarama
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/cloudevents/sdk-go/v2/test"
+)
+
+func TestRequester(t *testing.T) {
+	syncProducerMock := &syncProducerMock{}
+	consumerMock := mocks.NewConsumer(t, nil)
+	consumerMock.SetTopicMetadata(map[string][]int32{"replies": {0}})
+	partitionConsumerMock := consumerMock.ExpectConsumePartition("replies", 0, sarama.OffsetNewest)
+
+	requester, err := newRequester(consumerMock, &Sender{topic: "requests", syncProducer: syncProducerMock}, "replies")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, requester.Close(context.TODO())) }()
+
+	go func() {
+		var request *sarama.ProducerMessage
+		require.Eventually(t, func() bool {
+			syncProducerMock.lock.Lock()
+			defer syncProducerMock.lock.Unlock()
+			if len(syncProducerMock.sent) == 0 {
+				return false
+			}
+			request = syncProducerMock.sent[0]
+			return true
+		}, time.Second, 10*time.Millisecond)
+
+		var headers []*sarama.RecordHeader
+		for i := range request.Headers {
+			headers = append(headers, &request.Headers[i])
+		}
+		require.Equal(t, "replies", header(headers, ReplyTopicHeader))
+		correlationId := header(headers, CorrelationIdHeader)
+		require.NotEmpty(t, correlationId)
+
+		// A response to another request is discarded
+		partitionConsumerMock.YieldMessage(&sarama.ConsumerMessage{
+			Topic:   "replies",
+			Value:   []byte("other"),
+			Headers: []*sarama.RecordHeader{{Key: []byte(CorrelationIdHeader), Value: []byte("other")}},
+		})
+		partitionConsumerMock.YieldMessage(&sarama.ConsumerMessage{
+			Topic:   "replies",
+			Value:   []byte("response"),
+			Headers: []*sarama.RecordHeader{{Key: []byte(CorrelationIdHeader), Value: []byte(correlationId)}},
+		})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+	defer cancel()
+	resp, err := requester.Request(ctx, test.FullMessage())
+	require.NoError(t, err)
+	require.Equal(t, []byte("response"), resp.(*Message).Value)
+}
+
+func TestRequesterTimeout(t *testing.T) {
+	consumerMock := mocks.NewConsumer(t, nil)
+	consumerMock.SetTopicMetadata(map[string][]int32{"replies": {0}})
+	consumerMock.ExpectConsumePartition("replies", 0, sarama.OffsetNewest)
+
+	requester, err := newRequester(consumerMock, &Sender{topic: "requests", syncProducer: &syncProducerMock{}}, "replies")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, requester.Close(context.TODO())) }()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	_, err = requester.Request(ctx, test.FullMessage())
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestRequesterErrors(t *testing.T) {
+	config := sarama.NewConfig()
+	config.Consumer.Return.Errors = true
+	consumerMock := mocks.NewConsumer(t, config)
+	consumerMock.SetTopicMetadata(map[string][]int32{"replies": {0}})
+	partitionConsumerMock := consumerMock.ExpectConsumePartition("replies", 0, sarama.OffsetNewest)
+	partitionConsumerMock.ExpectErrorsDrainedOnClose()
+
+	logger := &warningsLogger{warnings: make(chan []interface{}, 1)}
+	cecontext.SetFallbackLogger(logger)
+	defer cecontext.SetFallbackLogger(nil)
+
+	requester, err := newRequester(consumerMock, &Sender{topic: "requests", syncProducer: &syncProducerMock{}}, "replies")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, requester.Close(context.TODO())) }()
+
+	fail := errors.New("unit test")
+	partitionConsumerMock.YieldError(fail)
+	select {
+	case kvs := <-logger.warnings:
+		require.Equal(t, []interface{}{"topic", "replies", "partition", int32(0), "error", fail}, kvs)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the error to be logged")
+	}
+}
+
+// warningsLogger records the keys and values of the warnings.
+type warningsLogger struct {
+	warnings chan []interface{}
+}
+
+func (l *warningsLogger) Debugw(string, ...interface{}) {}
+func (l *warningsLogger) Infow(string, ...interface{})  {}
+func (l *warningsLogger) Errorw(string, ...interface{}) {}
+func (l *warningsLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	l.warnings <- keysAndValues
+}
+func (l *warningsLogger) With(...interface{}) cecontext.Logger { return l }
+
+func TestProtocolRespond(t *testing.T) {
+	tests := map[string]struct {
+		headers []*sarama.RecordHeader
+		wantLen int
+	}{
+		"with reply topic": {
+			headers: []*sarama.RecordHeader{
+				{Key: []byte(ReplyTopicHeader), Value: []byte("replies")},
+				{Key: []byte(CorrelationIdHeader), Value: []byte("123")},
+			},
+			wantLen: 1,
+		},
+		"without reply topic": {
+			wantLen: 0,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			syncProducerMock := &syncProducerMock{}
+			p := &Protocol{
+				Sender:   &Sender{topic: "requests", syncProducer: syncProducerMock},
+				Consumer: NewConsumerFromClient(nil, "group", "requests"),
+			}
+
+			go func() {
+				p.Consumer.incoming <- msgErr{msg: NewMessageFromConsumerMessage(&sarama.ConsumerMessage{
+					Topic:   "requests",
+					Value:   []byte("request"),
+					Headers: tc.headers,
+				})}
+			}()
+
+			logger := &warningsLogger{warnings: make(chan []interface{}, 1)}
+			ctx := cecontext.WithStructuredLogger(context.TODO(), logger)
+			m, respFn, err := p.Respond(ctx)
+			require.NoError(t, err)
+			require.NoError(t, m.Finish(nil))
+			require.Equal(t, protocol.ResultACK, respFn(ctx, binding.Message(test.FullMessage()), protocol.ResultACK))
+
+			require.Len(t, syncProducerMock.sent, tc.wantLen)
+			if tc.wantLen > 0 {
+				response := syncProducerMock.sent[0]
+				require.Equal(t, "replies", response.Topic)
+				var headers []*sarama.RecordHeader
+				for i := range response.Headers {
+					headers = append(headers, &response.Headers[i])
+				}
+				require.Equal(t, "123", header(headers, CorrelationIdHeader))
+			} else {
+				// The topic of the request is logged
+				require.Equal(t, []interface{}{"topic", "requests"}, <-logger.warnings)
+			}
+		})
+	}
+}
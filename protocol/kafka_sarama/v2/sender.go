@@ -2,16 +2,21 @@ package kafka_sarama
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Shopify/sarama"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	"github.com/cloudevents/sdk-go/v2/types"
 )
 
 // Sender implements binding.Sender that sends messages to a specific receiverTopic using sarama.SyncProducer
 type Sender struct {
-	topic        string
-	syncProducer sarama.SyncProducer
+	topic          string
+	topicExtension string
+	keyExtractor   KeyExtractor
+	syncProducer   sarama.SyncProducer
 }
 
 // NewSender returns a binding.Sender that sends messages to a specific receiverTopic using sarama.SyncProducer
@@ -52,21 +57,19 @@ func makeSender(syncProducer sarama.SyncProducer, topic string, options ...Sende
 	return s
 }
 
+// Send implements Sender.Send, sending m to the topic of ctx set with
+// cecontext.WithTopic, or to the topic of the extension set with
+// WithTopicExtension, or to the topic of the sender.
 func (s *Sender) Send(ctx context.Context, m binding.Message, transformers ...binding.Transformer) error {
 	var err error
 	defer m.Finish(err)
 
-	kafkaMessage := sarama.ProducerMessage{Topic: s.topic}
-
-	if k := ctx.Value(withMessageKey{}); k != nil {
-		kafkaMessage.Key = k.(sarama.Encoder)
-	}
-
-	if err = WriteProducerMessage(ctx, m, &kafkaMessage, transformers...); err != nil {
+	var kafkaMessage *sarama.ProducerMessage
+	if kafkaMessage, err = newProducerMessage(ctx, s.topic, s.topicExtension, s.keyExtractor, m, transformers...); err != nil {
 		return err
 	}
 
-	_, _, err = s.syncProducer.SendMessage(&kafkaMessage)
+	_, _, err = s.syncProducer.SendMessage(kafkaMessage)
 	// Somebody closed the client while sending the message, so no problem here
 	if err == sarama.ErrClosedClient {
 		return nil
@@ -74,6 +77,52 @@ func (s *Sender) Send(ctx context.Context, m binding.Message, transformers ...bi
 	return err
 }
 
+// newProducerMessage returns the producer message of m, sent to the topic of ctx
+// set with cecontext.WithTopic, or to the topic of the topicExtension extension
+// of m, if any, or to topic. The key is extracted with keyExtractor, if not nil,
+// unless ctx has its own KeyExtractor.
+func newProducerMessage(ctx context.Context, topic string, topicExtension string, keyExtractor KeyExtractor, m binding.Message, transformers ...binding.Transformer) (*sarama.ProducerMessage, error) {
+	if keyExtractor != nil && ctx.Value(keyExtractorKey{}) == nil {
+		ctx = WithKeyExtractor(ctx, keyExtractor)
+	}
+
+	kafkaMessage := &sarama.ProducerMessage{Topic: topic}
+	if topic := cecontext.TopicFrom(ctx); topic != "" {
+		kafkaMessage.Topic = topic
+	} else if topic := extensionTopic(m, topicExtension); topic != "" {
+		kafkaMessage.Topic = topic
+	}
+	if kafkaMessage.Topic == "" {
+		return nil, errors.New("no topic to send the message to")
+	}
+
+	if k := ctx.Value(withMessageKey{}); k != nil {
+		kafkaMessage.Key = k.(sarama.Encoder)
+	}
+
+	if err := WriteProducerMessage(ctx, m, kafkaMessage, transformers...); err != nil {
+		return nil, err
+	}
+
+	if h := ctx.Value(withMessageHeaders{}); h != nil {
+		kafkaMessage.Headers = append(kafkaMessage.Headers, h.([]sarama.RecordHeader)...)
+	}
+	return kafkaMessage, nil
+}
+
+// extensionTopic returns the value of the extension of m, or an empty string.
+func extensionTopic(m binding.Message, extension string) string {
+	if extension == "" {
+		return ""
+	}
+	reader, ok := m.(binding.MessageMetadataReader)
+	if !ok {
+		return ""
+	}
+	topic, _ := types.ToString(reader.GetExtension(extension))
+	return topic
+}
+
 func (s *Sender) Close(ctx context.Context) error {
 	// If the Sender was built with NewSenderFromClient, this Close will close only the producer,
 	// otherwise it will close the whole client
@@ -86,3 +135,11 @@ type withMessageKey struct{}
 func WithMessageKey(ctx context.Context, key sarama.Encoder) context.Context {
 	return context.WithValue(ctx, withMessageKey{}, key)
 }
+
+type withMessageHeaders struct{}
+
+// WithMessageHeaders allows to add headers to the producer message, in addition
+// to the ones written from the message
+func WithMessageHeaders(ctx context.Context, headers ...sarama.RecordHeader) context.Context {
+	return context.WithValue(ctx, withMessageHeaders{}, headers)
+}
@@ -8,10 +8,12 @@ import (
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/require"
 
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/cloudevents/sdk-go/v2/test"
 )
 
 type syncProducerMock struct {
+	sarama.SyncProducer
 	lock sync.Mutex
 	sent []*sarama.ProducerMessage
 }
@@ -49,3 +51,35 @@ func TestSenderWithKey(t *testing.T) {
 	require.Equal(t, kafkaMsg.Topic, topic)
 	require.Equal(t, kafkaMsg.Key, sarama.StringEncoder("hello"))
 }
+
+func TestSenderWithTopic(t *testing.T) {
+	syncProducerMock := &syncProducerMock{}
+
+	sender := &Sender{topic: "aaa", syncProducer: syncProducerMock}
+	require.NoError(t, sender.Send(
+		cecontext.WithTopic(context.TODO(), "replies"),
+		test.FullMessage(),
+	))
+
+	require.Len(t, syncProducerMock.sent, 1)
+	require.Equal(t, "replies", syncProducerMock.sent[0].Topic)
+}
+
+func TestSenderWithTopicExtension(t *testing.T) {
+	syncProducerMock := &syncProducerMock{}
+
+	sender, err := NewSenderFromSyncProducer("aaa", syncProducerMock, WithTopicExtension("exstring"))
+	require.NoError(t, err)
+
+	// test.FullMessage has the exstring extension
+	require.NoError(t, sender.Send(context.TODO(), test.FullMessage()))
+	// The topic of the context has the precedence
+	require.NoError(t, sender.Send(cecontext.WithTopic(context.TODO(), "replies"), test.FullMessage()))
+	// Without the extension, the message is sent to the topic of the sender
+	require.NoError(t, sender.Send(context.TODO(), test.MinMessage()))
+
+	require.Len(t, syncProducerMock.sent, 3)
+	require.Equal(t, "exstring", syncProducerMock.sent[0].Topic)
+	require.Equal(t, "replies", syncProducerMock.sent[1].Topic)
+	require.Equal(t, "aaa", syncProducerMock.sent[2].Topic)
+}
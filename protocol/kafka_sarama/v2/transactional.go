@@ -0,0 +1,251 @@
+package kafka_sarama
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+// TransactionalHandlerFunc processes a message consumed by a
+// TransactionalProcessor, sending the resulting messages with sender, in the
+// transaction committing the offset of the consumed message.
+// An error aborts the transaction, and the message is consumed again: the
+// messages that can't be processed should be sent to a dead letter topic with
+// sender, returning nil.
+type TransactionalHandlerFunc func(ctx context.Context, m binding.Message, sender protocol.Sender) error
+
+// TransactionalDeadLetterFunc handles a message consumed by a
+// TransactionalProcessor after the handler failed the max attempts, see
+// WithMaxAttempts. It's invoked with the last error of the handler, in the
+// transaction committing the offset of the consumed message, e.g. to send the
+// message to a dead letter topic with sender.
+type TransactionalDeadLetterFunc func(ctx context.Context, m binding.Message, sender protocol.Sender, err error) error
+
+// TransactionalProcessor processes the messages of the consumed topics exactly
+// once (consume-transform-produce): for each consumed message, the messages
+// sent by the handler and the offset of the consumed message are committed in a
+// single Kafka transaction. When processing fails, the transaction is aborted
+// and the consumer group session ends, so the partitions are consumed again
+// from the committed offsets. The sessions following a failure start after an
+// exponential backoff. Use WithMaxAttempts to pass the messages that keep
+// failing to a dead letter handler, instead of consuming them again forever.
+//
+// The sarama.Config of the client requires Producer.Transaction.ID, unique for
+// each instance of the processor, Producer.Idempotent, Producer.RequiredAcks
+// set to sarama.WaitForAll, Producer.Return.Successes and Net.MaxOpenRequests
+// set to 1. The consumers of the produced topics read only the committed
+// messages with Consumer.IsolationLevel set to sarama.ReadCommitted.
+//
+// The transactions of the partitions are serialized, as the producer has one
+// transaction at a time.
+type TransactionalProcessor struct {
+	client   sarama.Client
+	producer sarama.SyncProducer
+	sender   *Sender
+	groupId  string
+	topics   []string
+	handler  TransactionalHandlerFunc
+
+	maxAttempts    int
+	deadLetter     TransactionalDeadLetterFunc
+	sessionBackoff time.Duration
+
+	txnMux sync.Mutex
+
+	failuresMux sync.Mutex
+	// failures counts the failed attempts of the offsets being processed
+	failures map[offsetKey]int
+	// aborted is true when a transaction was aborted in the current session
+	aborted bool
+}
+
+type offsetKey struct {
+	topic     string
+	partition int32
+	offset    int64
+}
+
+// NewTransactionalProcessorFromClient returns a TransactionalProcessor of the
+// messages of topics, consumed by the group groupId, invoking handler with a
+// Sender sending to topic.
+func NewTransactionalProcessorFromClient(client sarama.Client, groupId string, topics []string, topic string, handler TransactionalHandlerFunc, options ...TransactionalProcessorOptionFunc) (*TransactionalProcessor, error) {
+	if client.Config().Producer.Transaction.ID == "" {
+		return nil, errors.New("the transactional processor requires Producer.Transaction.ID in the sarama.Config")
+	}
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		return nil, err
+	}
+	p := makeTransactionalProcessor(producer, groupId, topics, topic, handler, options...)
+	p.client = client
+	return p, nil
+}
+
+func makeTransactionalProcessor(producer sarama.SyncProducer, groupId string, topics []string, topic string, handler TransactionalHandlerFunc, options ...TransactionalProcessorOptionFunc) *TransactionalProcessor {
+	p := &TransactionalProcessor{
+		producer:       producer,
+		sender:         makeSender(producer, topic),
+		groupId:        groupId,
+		topics:         topics,
+		handler:        handler,
+		sessionBackoff: defaultFailurePeriod,
+		failures:       make(map[offsetKey]int),
+	}
+	for _, o := range options {
+		o(p)
+	}
+	return p
+}
+
+// Process consumes and processes the messages until ctx is done, or the
+// producer fails with a fatal error, e.g. when it's fenced by another producer
+// with the same Producer.Transaction.ID. This call is blocking.
+func (p *TransactionalProcessor) Process(ctx context.Context) error {
+	cg, err := sarama.NewConsumerGroupFromClient(p.groupId, p.client)
+	if err != nil {
+		return err
+	}
+	defer cg.Close()
+
+	backoff := p.sessionBackoff
+	for {
+		// The session ends when a transaction is aborted
+		err := cg.Consume(ctx, p.topics, p)
+		if err == sarama.ErrClosedClient || err == sarama.ErrClosedConsumerGroup || ctx.Err() != nil {
+			return nil
+		}
+		if p.producer.TxnStatus()&sarama.ProducerTxnFlagFatalError != 0 {
+			return errors.New("the transactional producer failed with a fatal error")
+		}
+		if err != nil {
+			cecontext.StructuredLoggerFrom(ctx).Warnw("the consumer group session failed", "error", err)
+		} else if !p.takeAborted() {
+			// The session ended with a rebalance
+			backoff = p.sessionBackoff
+			continue
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		}
+		if backoff *= 2; backoff > maxFailureBackoff {
+			backoff = maxFailureBackoff
+		}
+	}
+}
+
+// takeAborted returns whether a transaction was aborted since the last call.
+func (p *TransactionalProcessor) takeAborted() bool {
+	p.failuresMux.Lock()
+	defer p.failuresMux.Unlock()
+	aborted := p.aborted
+	p.aborted = false
+	return aborted
+}
+
+// Setup implements sarama.ConsumerGroupHandler.Setup.
+func (p *TransactionalProcessor) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// Cleanup implements sarama.ConsumerGroupHandler.Cleanup.
+func (p *TransactionalProcessor) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim implements sarama.ConsumerGroupHandler.ConsumeClaim, processing
+// each message in a transaction. It returns the first error, which ends the
+// session.
+func (p *TransactionalProcessor) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	ctx := session.Context()
+	for message := range claim.Messages() {
+		if err := p.process(ctx, message); err != nil {
+			cecontext.StructuredLoggerFrom(ctx).Errorw("the transaction is aborted, the message will be consumed again",
+				"topic", message.Topic, "partition", message.Partition, "offset", message.Offset, "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// process processes message with the handler, or with the dead letter handler
+// when the handler fails the max attempts.
+func (p *TransactionalProcessor) process(ctx context.Context, message *sarama.ConsumerMessage) error {
+	err := p.transaction(ctx, message, p.handler)
+	if err != nil && p.deadLetter != nil && p.failed(message) >= p.maxAttempts {
+		cecontext.StructuredLoggerFrom(ctx).Warnw("the max attempts are exhausted, dead lettering the message",
+			"topic", message.Topic, "partition", message.Partition, "offset", message.Offset, "error", err)
+		handlerErr := err
+		err = p.transaction(ctx, message, func(ctx context.Context, m binding.Message, sender protocol.Sender) error {
+			return p.deadLetter(ctx, m, sender, handlerErr)
+		})
+	}
+
+	p.failuresMux.Lock()
+	defer p.failuresMux.Unlock()
+	if err != nil {
+		p.aborted = true
+	} else {
+		delete(p.failures, offsetKey{topic: message.Topic, partition: message.Partition, offset: message.Offset})
+	}
+	return err
+}
+
+// failed records a failed attempt of message, returning the number of failed
+// attempts.
+func (p *TransactionalProcessor) failed(message *sarama.ConsumerMessage) int {
+	p.failuresMux.Lock()
+	defer p.failuresMux.Unlock()
+	key := offsetKey{topic: message.Topic, partition: message.Partition, offset: message.Offset}
+	p.failures[key]++
+	return p.failures[key]
+}
+
+// transaction invokes handler with message and commits the sent messages and
+// the offset of message in a transaction.
+func (p *TransactionalProcessor) transaction(ctx context.Context, message *sarama.ConsumerMessage, handler TransactionalHandlerFunc) (err error) {
+	p.txnMux.Lock()
+	defer p.txnMux.Unlock()
+
+	if err := p.producer.BeginTxn(); err != nil {
+		return fmt.Errorf("failed to begin the transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if abortErr := p.producer.AbortTxn(); abortErr != nil {
+				err = fmt.Errorf("%w (and failed to abort the transaction: %v)", err, abortErr)
+			}
+		}
+	}()
+
+	if err := handler(ctx, NewMessageFromConsumerMessage(message), p.sender); err != nil {
+		return fmt.Errorf("failed to process the message: %w", err)
+	}
+	if err := p.producer.AddMessageToTxn(message, p.groupId, nil); err != nil {
+		return fmt.Errorf("failed to add the offset to the transaction: %w", err)
+	}
+	if err := p.producer.CommitTxn(); err != nil {
+		return fmt.Errorf("failed to commit the transaction: %w", err)
+	}
+	return nil
+}
+
+// Close closes the producer of the processor.
+func (p *TransactionalProcessor) Close(context.Context) error {
+	return p.producer.Close()
+}
+
+var _ sarama.ConsumerGroupHandler = (*TransactionalProcessor)(nil)
+var _ protocol.Closer = (*TransactionalProcessor)(nil)
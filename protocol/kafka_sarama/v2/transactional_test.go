@@ -0,0 +1,155 @@
+package kafka_sarama
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+// txnClientMock returns a fixed config.
+type txnClientMock struct {
+	sarama.Client
+	config *sarama.Config
+}
+
+func (c *txnClientMock) Config() *sarama.Config { return c.config }
+
+// txnProducerMock records the operations of the transactions.
+type txnProducerMock struct {
+	syncProducerMock
+	ops       []string
+	commitErr error
+}
+
+func (p *txnProducerMock) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	p.ops = append(p.ops, "send "+msg.Topic)
+	return p.syncProducerMock.SendMessage(msg)
+}
+
+func (p *txnProducerMock) BeginTxn() error {
+	p.ops = append(p.ops, "begin")
+	return nil
+}
+
+func (p *txnProducerMock) CommitTxn() error {
+	p.ops = append(p.ops, "commit")
+	return p.commitErr
+}
+
+func (p *txnProducerMock) AbortTxn() error {
+	p.ops = append(p.ops, "abort")
+	return nil
+}
+
+func (p *txnProducerMock) AddMessageToTxn(msg *sarama.ConsumerMessage, groupId string, _ *string) error {
+	p.ops = append(p.ops, "offset "+groupId+" "+msg.Topic+" "+strconv.FormatInt(msg.Offset+1, 10))
+	return nil
+}
+
+func TestTransactionalProcessor(t *testing.T) {
+	tests := map[string]struct {
+		commitErr  error
+		handlerErr error
+		wantOps    []string
+	}{
+		"committed": {
+			wantOps: []string{
+				"begin", "send out", "send dlq", "offset group aaa 1", "commit",
+				"begin", "send out", "send dlq", "offset group aaa 2", "commit",
+			},
+		},
+		"handler failure": {
+			handlerErr: errors.New("boom"),
+			wantOps:    []string{"begin", "send out", "abort"},
+		},
+		"commit failure": {
+			commitErr: errors.New("unavailable"),
+			wantOps:   []string{"begin", "send out", "send dlq", "offset group aaa 1", "commit", "abort"},
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			producer := &txnProducerMock{commitErr: tc.commitErr}
+			p := makeTransactionalProcessor(producer, "group", []string{"aaa"}, "out",
+				func(ctx context.Context, m binding.Message, sender protocol.Sender) error {
+					if err := sender.Send(ctx, m); err != nil {
+						return err
+					}
+					if tc.handlerErr != nil {
+						return tc.handlerErr
+					}
+					return sender.Send(cecontext.WithTopic(ctx, "dlq"), NewMessageFromConsumerMessage(consumerMessage(0)))
+				})
+
+			claim := &claimMock{messages: make(chan *sarama.ConsumerMessage, 2)}
+			claim.messages <- consumerMessage(0)
+			claim.messages <- consumerMessage(1)
+			close(claim.messages)
+			err := p.ConsumeClaim(&sessionMock{ctx: context.TODO()}, claim)
+
+			if tc.handlerErr != nil || tc.commitErr != nil {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantOps, producer.ops)
+		})
+	}
+}
+
+func TestTransactionalProcessor_MaxAttempts(t *testing.T) {
+	producer := &txnProducerMock{}
+	fail := errors.New("boom")
+	var deadLettered error
+	p := makeTransactionalProcessor(producer, "group", []string{"aaa"}, "out",
+		func(ctx context.Context, m binding.Message, sender protocol.Sender) error {
+			if kafkaMessage := m.(*Message); kafkaMessage.Headers["fail"] != nil {
+				return fail
+			}
+			return sender.Send(ctx, m)
+		},
+		WithMaxAttempts(2, func(ctx context.Context, m binding.Message, sender protocol.Sender, err error) error {
+			deadLettered = err
+			return sender.Send(cecontext.WithTopic(ctx, "dlq"), m)
+		}),
+	)
+
+	consume := func() error {
+		failing := consumerMessage(0, &sarama.RecordHeader{Key: []byte("fail"), Value: []byte("true")})
+		claim := &claimMock{messages: make(chan *sarama.ConsumerMessage, 2)}
+		claim.messages <- failing
+		claim.messages <- consumerMessage(1)
+		close(claim.messages)
+		return p.ConsumeClaim(&sessionMock{ctx: context.TODO()}, claim)
+	}
+
+	require.Error(t, consume())
+	require.Equal(t, []string{"begin", "abort"}, producer.ops)
+	require.True(t, p.takeAborted())
+	require.False(t, p.takeAborted())
+
+	producer.ops = nil
+	require.NoError(t, consume())
+	require.Equal(t, []string{
+		"begin", "abort",
+		"begin", "send dlq", "offset group aaa 1", "commit",
+		"begin", "send out", "offset group aaa 2", "commit",
+	}, producer.ops)
+	require.True(t, errors.Is(deadLettered, fail))
+	require.False(t, p.takeAborted())
+	require.Empty(t, p.failures)
+}
+
+func TestNewTransactionalProcessorFromClient(t *testing.T) {
+	client := &txnClientMock{config: sarama.NewConfig()}
+	_, err := NewTransactionalProcessorFromClient(client, "group", []string{"aaa"}, "out", nil)
+	require.Error(t, err)
+}
@@ -10,17 +10,16 @@ import (
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/format"
 	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/types"
 )
 
-const (
-	partitionKey = "partitionkey"
-)
-
 // WriteProducerMessage fills the provided producerMessage with the message m.
 // Using context you can tweak the encoding processing (more details on binding.Write documentation).
-// By default, this function implements the key mapping, trying to set the key of the message based on partitionKey extension.
+// By default, this function implements the key mapping, trying to set the key of the message based on the partitionkey extension.
+// If you want to use another key mapping, decorate the context with `WithKeyExtractor`.
 // If you want to disable the Key Mapping, decorate the context with `WithSkipKeyMapping`
+// The trace context of the span in ctx, if any, is written in the W3C traceparent and tracestate headers.
 func WriteProducerMessage(ctx context.Context, m binding.Message, producerMessage *sarama.ProducerMessage, transformers ...binding.Transformer) error {
 	writer := (*kafkaProducerMessageWriter)(producerMessage)
 
@@ -30,16 +29,20 @@ func WriteProducerMessage(ctx context.Context, m binding.Message, producerMessag
 
 	// If skipKey = false, then we add a transformer that extracts the key
 	if !skipKey {
-		transformers = append(transformers, binding.TransformerFunc(func(r binding.MessageMetadataReader, w binding.MessageMetadataWriter) error {
-			ext := r.GetExtension(partitionKey)
-			if !types.IsZero(ext) {
-				extStr, err := types.Format(ext)
+		extractor := keyExtractorFrom(ctx)
+		if requiresEvent(extractor) {
+			if m.ReadEncoding() != binding.EncodingEvent {
+				e, err := binding.ToEvent(ctx, m)
 				if err != nil {
 					return err
 				}
-				key = extStr
+				m = (*binding.EventMessage)(e)
 			}
-			return nil
+		}
+		transformers = append(transformers, binding.TransformerFunc(func(r binding.MessageMetadataReader, w binding.MessageMetadataWriter) error {
+			var err error
+			key, err = extractor.ExtractKey(r)
+			return err
 		}))
 	}
 
@@ -53,6 +56,18 @@ func WriteProducerMessage(ctx context.Context, m binding.Message, producerMessag
 	if key != "" {
 		producerMessage.Key = sarama.StringEncoder(key)
 	}
+	if tc, ok := extensions.TraceContextFromContext(ctx); ok && err == nil {
+		producerMessage.Headers = append(producerMessage.Headers, sarama.RecordHeader{
+			Key:   []byte(extensions.TraceParentHeader),
+			Value: []byte(tc.TraceParent),
+		})
+		if tc.TraceState != "" {
+			producerMessage.Headers = append(producerMessage.Headers, sarama.RecordHeader{
+				Key:   []byte(extensions.TraceStateHeader),
+				Value: []byte(tc.TraceState),
+			})
+		}
+	}
 	return err
 }
 
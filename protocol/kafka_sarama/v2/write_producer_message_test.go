@@ -7,10 +7,12 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
 	. "github.com/cloudevents/sdk-go/v2/binding/test"
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	. "github.com/cloudevents/sdk-go/v2/test"
 )
 
@@ -153,7 +155,7 @@ func TestEncodeKafkaProducerMessage(t *testing.T) {
 
 				eventIn := ConvertEventExtensionsToString(t, e.Clone())
 				if tt.addPartitionKey {
-					eventIn.SetExtension(partitionKey, testKey)
+					eventIn.SetExtension(extensions.PartitionKeyExtension, testKey)
 				}
 				messageIn := tt.messageFactory(eventIn)
 
@@ -192,3 +194,21 @@ func TestEncodeKafkaProducerMessage(t *testing.T) {
 	})
 
 }
+
+func TestWriteProducerMessage_TraceContext(t *testing.T) {
+	ctx, span := trace.StartSpan(context.Background(), "test")
+	defer span.End()
+
+	eventIn := FullEvent()
+	producerMessage := &sarama.ProducerMessage{}
+	require.NoError(t, WriteProducerMessage(ctx, binding.ToMessage(&eventIn), producerMessage))
+
+	var headers []*sarama.RecordHeader
+	for i := range producerMessage.Headers {
+		headers = append(headers, &producerMessage.Headers[i])
+	}
+	m := NewMessageFromConsumerMessage(&sarama.ConsumerMessage{Headers: headers})
+	tc, ok := m.ReadTraceContext()
+	require.True(t, ok)
+	require.Equal(t, extensions.FromSpanContext(span.SpanContext()), tc)
+}
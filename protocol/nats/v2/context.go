@@ -0,0 +1,43 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+// ProtocolContext holds the NATS metadata of a received message.
+type ProtocolContext struct {
+	Subject string
+	Reply   string
+}
+
+// NewProtocolContext creates a new ProtocolContext from a nats.Msg.
+func NewProtocolContext(msg *nats.Msg) ProtocolContext {
+	if msg == nil {
+		return ProtocolContext{}
+	}
+	return ProtocolContext{
+		Subject: msg.Subject,
+		Reply:   msg.Reply,
+	}
+}
+
+// Opaque key type used to store ProtocolContext
+type protocolContextKeyType struct{}
+
+var protocolContextKey = protocolContextKeyType{}
+
+// WithProtocolContext returns a context with the given ProtocolContext into the provided context object.
+func WithProtocolContext(ctx context.Context, pctx ProtocolContext) context.Context {
+	return context.WithValue(ctx, protocolContextKey, pctx)
+}
+
+// ProtocolContextFrom pulls a ProtocolContext out of a context, or returns a
+// zero ProtocolContext.
+func ProtocolContextFrom(ctx context.Context) ProtocolContext {
+	if pctx, ok := ctx.Value(protocolContextKey).(ProtocolContext); ok {
+		return pctx
+	}
+	return ProtocolContext{}
+}
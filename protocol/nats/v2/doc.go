@@ -1,4 +1,11 @@
 /*
 Package nats implements the CloudEvent transport implementation using NATS.
+
+The events are always sent in structured mode. The W3C trace context of the
+last hop is propagated in the traceparent and tracestate headers of the NATS
+messages, when the server supports headers (NATS Server 2.2 or later).
+
+The Protocol is also a Requester and a Responder, using the NATS request/reply
+pattern: the responses are published to the inbox subject of the requests.
 */
 package nats
@@ -1,6 +1,8 @@
 module github.com/cloudevents/sdk-go/protocol/nats/v2
 
-go 1.14
+// nats.go v1.11, the first release supporting the message headers, requires
+// go 1.16.
+go 1.16
 
 replace github.com/cloudevents/sdk-go/v2 => ../../../v2
 
@@ -8,5 +10,5 @@ require (
 	github.com/cloudevents/sdk-go/v2 v2.0.0
 	github.com/google/go-cmp v0.4.1 // indirect
 	github.com/nats-io/nats-server/v2 v2.1.7 // indirect
-	github.com/nats-io/nats.go v1.10.0
+	github.com/nats-io/nats.go v1.11.0
 )
@@ -6,6 +6,7 @@ import (
 
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/format"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/nats-io/nats.go"
 )
 
@@ -23,6 +24,8 @@ func NewMessage(msg *nats.Msg) *Message {
 }
 
 var _ binding.Message = (*Message)(nil)
+var _ binding.ProtocolContextMessage = (*Message)(nil)
+var _ extensions.TraceContextReader = (*Message)(nil)
 
 func (m *Message) ReadEncoding() binding.Encoding {
 	return m.encoding
@@ -36,6 +39,22 @@ func (m *Message) ReadBinary(ctx context.Context, encoder binding.BinaryWriter)
 	return binding.ErrNotBinary
 }
 
+// ReadTraceContext implements extensions.TraceContextReader, reading the W3C
+// traceparent and tracestate headers.
+func (m *Message) ReadTraceContext() (extensions.DistributedTracingExtension, bool) {
+	tp := m.Msg.Header.Get(extensions.TraceParentHeader)
+	if tp == "" {
+		return extensions.DistributedTracingExtension{}, false
+	}
+	return extensions.DistributedTracingExtension{TraceParent: tp, TraceState: m.Msg.Header.Get(extensions.TraceStateHeader)}, true
+}
+
+// WithProtocolContext implements binding.ProtocolContextMessage, attaching the
+// ProtocolContext of the received message.
+func (m *Message) WithProtocolContext(ctx context.Context) context.Context {
+	return WithProtocolContext(ctx, NewProtocolContext(m.Msg))
+}
+
 func (m *Message) Finish(err error) error {
 	return nil
 }
@@ -0,0 +1,37 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/cloudevents/sdk-go/v2/extensions"
+)
+
+func TestTraceContext(t *testing.T) {
+	want := extensions.DistributedTracingExtension{
+		TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		TraceState:  "rojo=00f067aa0ba902b7",
+	}
+	ctx := extensions.ContextWithTraceContext(context.Background(), want)
+
+	msg := nats.NewMsg("subject")
+	writeTraceContext(ctx, msg)
+	if got := msg.Header.Get("traceparent"); got != want.TraceParent {
+		t.Errorf("unexpected traceparent header. got: %q, want: %q", got, want.TraceParent)
+	}
+	tc, ok := NewMessage(msg).ReadTraceContext()
+	if !ok || tc != want {
+		t.Errorf("unexpected trace context. got: %v, want: %v", tc, want)
+	}
+
+	msg = &nats.Msg{Subject: "subject"}
+	writeTraceContext(context.Background(), msg)
+	if msg.Header != nil {
+		t.Errorf("unexpected headers: %v", msg.Header)
+	}
+	if _, ok := NewMessage(msg).ReadTraceContext(); ok {
+		t.Errorf("unexpected trace context")
+	}
+}
@@ -2,6 +2,7 @@ package nats
 
 import (
 	"errors"
+	"time"
 
 	"github.com/nats-io/nats.go"
 )
@@ -35,6 +36,16 @@ type SenderOption func(*Sender) error
 
 type ConsumerOption func(*Consumer) error
 
+// WithRequestTimeout sets the timeout of the requests sent with a context
+// without deadline. By default, such requests wait for a response until the
+// context is canceled.
+func WithRequestTimeout(timeout time.Duration) SenderOption {
+	return func(s *Sender) error {
+		s.RequestTimeout = timeout
+		return nil
+	}
+}
+
 // WithQueueSubscriber configures the Consumer to join a queue group when subscribing
 func WithQueueSubscriber(queue string) ConsumerOption {
 	return func(c *Consumer) error {
@@ -66,6 +66,11 @@ func (p *Protocol) Send(ctx context.Context, in binding.Message, transformers ..
 	return p.Sender.Send(ctx, in, transformers...)
 }
 
+// Request implements Requester.Request
+func (p *Protocol) Request(ctx context.Context, in binding.Message, transformers ...binding.Transformer) (binding.Message, error) {
+	return p.Sender.Request(ctx, in, transformers...)
+}
+
 func (p *Protocol) OpenInbound(ctx context.Context) error {
 	return p.Consumer.OpenInbound(ctx)
 }
@@ -75,6 +80,11 @@ func (p *Protocol) Receive(ctx context.Context) (binding.Message, error) {
 	return p.Consumer.Receive(ctx)
 }
 
+// Respond implements Responder.Respond
+func (p *Protocol) Respond(ctx context.Context) (binding.Message, protocol.ResponseFn, error) {
+	return p.Consumer.Respond(ctx)
+}
+
 // Close implements Closer.Close
 func (p *Protocol) Close(ctx context.Context) error {
 	if p.connOwned {
@@ -103,5 +113,7 @@ func (p *Protocol) applyOptions(opts ...ProtocolOption) error {
 
 var _ protocol.Receiver = (*Protocol)(nil)
 var _ protocol.Sender = (*Protocol)(nil)
+var _ protocol.Requester = (*Protocol)(nil)
+var _ protocol.Responder = (*Protocol)(nil)
 var _ protocol.Opener = (*Protocol)(nil)
 var _ protocol.Closer = (*Protocol)(nil)
@@ -8,6 +8,7 @@ import (
 	"github.com/nats-io/nats.go"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 )
 
@@ -109,6 +110,41 @@ func (c *Consumer) OpenInbound(ctx context.Context) error {
 	return sub.Drain()
 }
 
+// Respond implements Responder.Respond. The ResponseFn publishes the response
+// message, if any, to the reply subject of the received message, and returns
+// the result of the received message. Without response message nothing is
+// published, and the requester times out.
+func (c *Consumer) Respond(ctx context.Context) (binding.Message, protocol.ResponseFn, error) {
+	in, err := c.Receive(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var reply string
+	if m, ok := in.(*Message); ok {
+		reply = m.Msg.Reply
+	}
+	return in, func(ctx context.Context, m binding.Message, r protocol.Result, transformers ...binding.Transformer) (err error) {
+		if m == nil {
+			return r
+		}
+		defer func() {
+			_ = m.Finish(err)
+		}()
+		if reply == "" {
+			cecontext.StructuredLoggerFrom(ctx).Debugw("dropping the response of a message without reply subject", "subject", c.Subject)
+			return r
+		}
+		msg, err := newMsg(ctx, c.Conn, reply, m, transformers...)
+		if err != nil {
+			return err
+		}
+		if err = c.Conn.PublishMsg(msg); err != nil {
+			return err
+		}
+		return r
+	}, nil
+}
+
 func (c *Consumer) Close(ctx context.Context) error {
 	// Before closing, let's be sure OpenInbound completes
 	// We send a signal to close and then we lock on subMtx in order
@@ -137,4 +173,5 @@ func (c *Consumer) applyOptions(opts ...ConsumerOption) error {
 
 var _ protocol.Opener = (*Consumer)(nil)
 var _ protocol.Receiver = (*Consumer)(nil)
+var _ protocol.Responder = (*Consumer)(nil)
 var _ protocol.Closer = (*Consumer)(nil)
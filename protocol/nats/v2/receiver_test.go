@@ -0,0 +1,49 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+func TestReceiver_ProtocolContext(t *testing.T) {
+	r := NewReceiver()
+	go r.MsgHandler(&nats.Msg{Subject: "subject", Reply: "reply"})
+	m, err := r.Receive(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := ProtocolContext{Subject: "subject", Reply: "reply"}
+	// The client wraps the received messages, e.g. to record the metrics
+	for _, msg := range []binding.Message{m, binding.WithFinish(m, nil)} {
+		if got := ProtocolContextFrom(binding.WithProtocolContext(context.TODO(), msg)); got != want {
+			t.Errorf("unexpected protocol context. got: %v, want: %v", got, want)
+		}
+	}
+}
+
+func TestRespond_NoReply(t *testing.T) {
+	c := &Consumer{Receiver: *NewReceiver(), Subject: "subject"}
+	go c.MsgHandler(&nats.Msg{Subject: "subject"})
+	_, respFn, err := c.Respond(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := respFn(context.TODO(), nil, protocol.ResultNACK); err != protocol.ResultNACK {
+		t.Errorf("unexpected result without response. got: %v, want: %v", err, protocol.ResultNACK)
+	}
+	finished := false
+	resp := binding.WithFinish(NewMessage(&nats.Msg{}), func(error) { finished = true })
+	if err := respFn(context.TODO(), resp, protocol.ResultACK); err != protocol.ResultACK {
+		t.Errorf("unexpected result without reply subject. got: %v, want: %v", err, protocol.ResultACK)
+	}
+	if !finished {
+		t.Errorf("the response is not finished")
+	}
+}
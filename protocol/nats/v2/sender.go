@@ -1,10 +1,12 @@
 package nats
 
 import (
-	"bytes"
 	"context"
 	"fmt"
+	"time"
+
 	"github.com/cloudevents/sdk-go/v2/binding"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 
 	"github.com/nats-io/nats.go"
@@ -13,6 +15,9 @@ import (
 type Sender struct {
 	Conn    *nats.Conn
 	Subject string
+	// RequestTimeout is the timeout of the requests sent with a context
+	// without deadline, 0 meaning no timeout.
+	RequestTimeout time.Duration
 
 	connOwned bool
 }
@@ -51,6 +56,8 @@ func NewSenderFromConn(conn *nats.Conn, subject string, opts ...SenderOption) (*
 	return s, nil
 }
 
+// Send implements Sender.Send, publishing in to the subject of the sender, or to
+// the subject of ctx set with cecontext.WithTopic.
 func (s *Sender) Send(ctx context.Context, in binding.Message, transformers ...binding.Transformer) (err error) {
 	defer func() {
 		if err2 := in.Finish(err); err2 != nil {
@@ -62,11 +69,51 @@ func (s *Sender) Send(ctx context.Context, in binding.Message, transformers ...b
 		}
 	}()
 
-	writer := new(bytes.Buffer)
-	if err = WriteMsg(ctx, in, writer, transformers...); err != nil {
+	subject := s.Subject
+	if topic := cecontext.TopicFrom(ctx); topic != "" {
+		subject = topic
+	}
+	msg, err := newMsg(ctx, s.Conn, subject, in, transformers...)
+	if err != nil {
 		return err
 	}
-	return s.Conn.Publish(s.Subject, writer.Bytes())
+	return s.Conn.PublishMsg(msg)
+}
+
+// Request implements Requester.Request, publishing in to the subject of the
+// sender, or to the subject of ctx set with cecontext.WithTopic, and waiting for
+// a response on an inbox subject until ctx is done or the RequestTimeout
+// expires.
+func (s *Sender) Request(ctx context.Context, in binding.Message, transformers ...binding.Transformer) (resp binding.Message, err error) {
+	defer func() {
+		if err2 := in.Finish(err); err2 != nil {
+			if err == nil {
+				err = err2
+			} else {
+				err = fmt.Errorf("failed to call in.Finish() when error already occurred: %s: %w", err2.Error(), err)
+			}
+		}
+	}()
+
+	subject := s.Subject
+	if topic := cecontext.TopicFrom(ctx); topic != "" {
+		subject = topic
+	}
+	msg, err := newMsg(ctx, s.Conn, subject, in, transformers...)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := ctx.Deadline(); !ok && s.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.RequestTimeout)
+		defer cancel()
+	}
+
+	reply, err := s.Conn.RequestMsgWithContext(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	return NewMessage(reply), nil
 }
 
 // Close implements Closer.Close
@@ -89,4 +136,5 @@ func (s *Sender) applyOptions(opts ...SenderOption) error {
 }
 
 var _ protocol.Sender = (*Sender)(nil)
+var _ protocol.Requester = (*Sender)(nil)
 var _ protocol.Closer = (*Protocol)(nil)
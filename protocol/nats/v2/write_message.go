@@ -1,10 +1,14 @@
 package nats
 
 import (
+	"bytes"
 	"context"
+	"io"
+
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/format"
-	"io"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+	"github.com/nats-io/nats.go"
 )
 
 // WriteMsg fills the provided writer with the bindings.Message m.
@@ -22,6 +26,37 @@ func WriteMsg(ctx context.Context, m binding.Message, writer io.ReaderFrom, tran
 	return err
 }
 
+// newMsg returns the NATS message publishing m to subject. The trace context of
+// the span in ctx, if any, is written in the W3C traceparent and tracestate
+// headers when the server of conn supports headers.
+func newMsg(ctx context.Context, conn *nats.Conn, subject string, m binding.Message, transformers ...binding.Transformer) (*nats.Msg, error) {
+	writer := new(bytes.Buffer)
+	if err := WriteMsg(ctx, m, writer, transformers...); err != nil {
+		return nil, err
+	}
+	msg := &nats.Msg{Subject: subject, Data: writer.Bytes()}
+	if conn.HeadersSupported() {
+		writeTraceContext(ctx, msg)
+	}
+	return msg, nil
+}
+
+// writeTraceContext writes the trace context of the span in ctx, if any, in the
+// headers of msg.
+func writeTraceContext(ctx context.Context, msg *nats.Msg) {
+	tc, ok := extensions.TraceContextFromContext(ctx)
+	if !ok {
+		return
+	}
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+	msg.Header.Set(extensions.TraceParentHeader, tc.TraceParent)
+	if tc.TraceState != "" {
+		msg.Header.Set(extensions.TraceStateHeader, tc.TraceState)
+	}
+}
+
 type natsMessageWriter struct {
 	io.ReaderFrom
 }
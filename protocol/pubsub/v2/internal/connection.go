@@ -61,6 +61,10 @@ type Connection struct {
 	// Default is 30 seconds.
 	// This can only be set prior to first call of any function.
 	AckDeadline *time.Duration
+	// MessageOrdering enables the ordered publishing of the messages with an
+	// ordering key, and the ordered delivery on the created subscriptions.
+	// Without it, the ordering key of the published messages is dropped.
+	MessageOrdering bool
 	// RetentionDuration is Pub/Sub RetentionDuration.
 	// Default is 25 hours.
 	// This can only be set prior to first call of any function.
@@ -114,6 +118,7 @@ func (c *Connection) getOrCreateTopicInfo(ctx context.Context, getAlreadyOpenOnl
 			}
 			ti.wasCreated = true
 		}
+		topic.EnableMessageOrdering = c.MessageOrdering
 		// Success.
 		ti.topic = topic
 	})
@@ -216,9 +221,10 @@ func (c *Connection) getOrCreateSubscriptionInfo(ctx context.Context, getAlready
 			// with the given name.
 			// TODO: allow to use push config + allow setting the SubscriptionConfig.
 			sub, si.err = c.Client.CreateSubscription(ctx, c.SubscriptionID, pubsub.SubscriptionConfig{
-				Topic:             topic,
-				AckDeadline:       *c.AckDeadline,
-				RetentionDuration: *c.RetentionDuration,
+				Topic:                 topic,
+				AckDeadline:           *c.AckDeadline,
+				RetentionDuration:     *c.RetentionDuration,
+				EnableMessageOrdering: c.MessageOrdering,
 			})
 			if si.err != nil {
 				return
@@ -287,8 +293,16 @@ func (c *Connection) Publish(ctx context.Context, msg *pubsub.Message) (*binding
 		return nil, err
 	}
 
+	if !c.MessageOrdering {
+		msg.OrderingKey = ""
+	}
 	r := topic.Publish(ctx, msg)
 	_, err = r.Get(ctx)
+	if err != nil && msg.OrderingKey != "" {
+		// A failure pauses the publishing of the ordering key: the caller
+		// decides whether to retry, so resume it.
+		topic.ResumePublish(msg.OrderingKey)
+	}
 	return nil, err
 }
 
@@ -6,9 +6,11 @@ import (
 	"strings"
 
 	"cloud.google.com/go/pubsub"
+	pscontext "github.com/cloudevents/sdk-go/protocol/pubsub/v2/context"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/format"
 	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 )
 
 const (
@@ -16,18 +18,24 @@ const (
 	contentType = "Content-Type"
 )
 
-var specs = spec.WithPrefix(prefix)
+var (
+	specs              = spec.WithPrefix(prefix)
+	partitionKeyHeader = prefix + extensions.PartitionKeyExtension
+)
 
 // Message represents a Pub/Sub message.
 // This message *can* be read several times safely
 type Message struct {
-	internal *pubsub.Message
-	format   format.Format
-	version  spec.Version
+	internal        *pubsub.Message
+	format          format.Format
+	version         spec.Version
+	protocolContext *pscontext.ProtocolContext
 }
 
 // NewMessage returns a binding.Message with data and attributes.
 // This message *can* be read several times safely
+// The ordering key of a binary message without partitionkey extension is
+// read as the partitionkey extension.
 func NewMessage(pm *pubsub.Message) *Message {
 	var f format.Format = nil
 	var version spec.Version = nil
@@ -53,6 +61,18 @@ func NewMessage(pm *pubsub.Message) *Message {
 // Check if pubsub.Message implements binding.Message
 var _ binding.Message = (*Message)(nil)
 var _ binding.MessageMetadataReader = (*Message)(nil)
+var _ extensions.TraceContextReader = (*Message)(nil)
+var _ binding.ProtocolContextMessage = (*Message)(nil)
+
+// ReadTraceContext implements extensions.TraceContextReader, reading the W3C
+// traceparent and tracestate attributes.
+func (m *Message) ReadTraceContext() (extensions.DistributedTracingExtension, bool) {
+	tp, ok := m.internal.Attributes[extensions.TraceParentHeader]
+	if !ok {
+		return extensions.DistributedTracingExtension{}, false
+	}
+	return extensions.DistributedTracingExtension{TraceParent: tp, TraceState: m.internal.Attributes[extensions.TraceStateHeader]}, true
+}
 
 func (m *Message) ReadEncoding() binding.Encoding {
 	if m.version != nil {
@@ -94,6 +114,11 @@ func (m *Message) ReadBinary(ctx context.Context, encoder binding.BinaryWriter)
 			return err
 		}
 	}
+	if key := m.orderingKey(); key != "" {
+		if err = encoder.SetExtension(extensions.PartitionKeyExtension, key); err != nil {
+			return err
+		}
+	}
 
 	if m.internal.Data != nil {
 		return encoder.SetData(bytes.NewBuffer(m.internal.Data))
@@ -111,9 +136,32 @@ func (m *Message) GetAttribute(k spec.Kind) (spec.Attribute, interface{}) {
 }
 
 func (m *Message) GetExtension(name string) interface{} {
+	if name == extensions.PartitionKeyExtension {
+		if key := m.orderingKey(); key != "" {
+			return key
+		}
+	}
 	return m.internal.Attributes[prefix+name]
 }
 
+// orderingKey returns the ordering key of the message when it's not shadowed
+// by a partitionkey attribute.
+func (m *Message) orderingKey() string {
+	if _, ok := m.internal.Attributes[partitionKeyHeader]; ok {
+		return ""
+	}
+	return m.internal.OrderingKey
+}
+
+// WithProtocolContext implements binding.ProtocolContextMessage, attaching the
+// ProtocolContext of the subscription the message was received from.
+func (m *Message) WithProtocolContext(ctx context.Context) context.Context {
+	if m.protocolContext == nil {
+		return ctx
+	}
+	return pscontext.WithProtocolContext(ctx, *m.protocolContext)
+}
+
 // Finish marks the message to be forgotten.
 // If err is nil, the underlying Psubsub message will be acked;
 // otherwise nacked.
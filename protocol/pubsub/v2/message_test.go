@@ -5,8 +5,11 @@ import (
 	"testing"
 
 	"cloud.google.com/go/pubsub"
+	"go.opencensus.io/trace"
+
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 )
 
 func TestReadStructured(t *testing.T) {
@@ -40,3 +43,80 @@ func TestReadStructured(t *testing.T) {
 		})
 	}
 }
+
+func TestPartitionKeyToOrderingKey(t *testing.T) {
+	e := event.New()
+	e.SetID("testid")
+	e.SetType("example.type")
+	e.SetSource("example/uri")
+	extensions.SetPartitionKey(&e, "key")
+
+	pm := &pubsub.Message{}
+	if err := WritePubSubMessage(context.Background(), binding.ToMessage(&e), pm); err != nil {
+		t.Fatal(err)
+	}
+	if pm.OrderingKey != "key" {
+		t.Errorf("unexpected ordering key. got: %q, want: %q", pm.OrderingKey, "key")
+	}
+
+	// The ordering key is read back as partitionkey, unless shadowed by the attribute
+	delete(pm.Attributes, partitionKeyHeader)
+	for _, attr := range []string{"", "attr"} {
+		if attr != "" {
+			pm.Attributes[partitionKeyHeader] = attr
+		}
+		got, err := binding.ToEvent(context.Background(), NewMessage(pm))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "key"
+		if attr != "" {
+			want = attr
+		}
+		if key, _ := extensions.GetPartitionKey(*got); key != want {
+			t.Errorf("unexpected partitionkey. got: %q, want: %q", key, want)
+		}
+	}
+}
+
+func TestWriteStructuredAsIs(t *testing.T) {
+	structured := []byte(`{"specversion":"1.0","id":"testid","type":"example.type","source":"example/uri","data":{"hello":"world"}}`)
+	in := NewMessage(&pubsub.Message{
+		Data:        structured,
+		Attributes:  map[string]string{contentType: event.ApplicationCloudEventsJSON},
+		OrderingKey: "key",
+	})
+	if in.ReadEncoding() != binding.EncodingStructured {
+		t.Fatalf("unexpected encoding: %v", in.ReadEncoding())
+	}
+
+	pm := &pubsub.Message{}
+	if err := WritePubSubMessage(context.Background(), in, pm); err != nil {
+		t.Fatal(err)
+	}
+	if string(pm.Data) != string(structured) {
+		t.Errorf("the structured message was re-encoded. got: %s", pm.Data)
+	}
+	if pm.OrderingKey != "key" {
+		t.Errorf("unexpected ordering key. got: %q, want: %q", pm.OrderingKey, "key")
+	}
+}
+
+func TestTraceContext(t *testing.T) {
+	ctx, span := trace.StartSpan(context.Background(), "test")
+	defer span.End()
+
+	e := event.New()
+	e.SetID("testid")
+	e.SetType("example.type")
+	e.SetSource("example/uri")
+
+	pm := &pubsub.Message{}
+	if err := WritePubSubMessage(ctx, binding.ToMessage(&e), pm); err != nil {
+		t.Fatal(err)
+	}
+	tc, ok := NewMessage(pm).ReadTraceContext()
+	if want := extensions.FromSpanContext(span.SpanContext()); !ok || tc != want {
+		t.Errorf("unexpected trace context. got: %v, want: %v", tc, want)
+	}
+}
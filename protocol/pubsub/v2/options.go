@@ -154,3 +154,14 @@ func WithReceiveSettings(rs *pubsub.ReceiveSettings) Option {
 		return nil
 	}
 }
+
+// WithMessageOrdering enables the message ordering: the messages with the same
+// partitionkey extension are published in order, and delivered in order on the
+// subscriptions created by the transport. Existing subscriptions must be created
+// with message ordering enabled.
+func WithMessageOrdering() Option {
+	return func(t *Protocol) error {
+		t.MessageOrdering = true
+		return nil
+	}
+}
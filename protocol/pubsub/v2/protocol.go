@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"cloud.google.com/go/pubsub"
+	pscontext "github.com/cloudevents/sdk-go/protocol/pubsub/v2/context"
 	"github.com/cloudevents/sdk-go/protocol/pubsub/v2/internal"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	cecontext "github.com/cloudevents/sdk-go/v2/context"
@@ -30,6 +31,10 @@ type Protocol struct {
 	// ReceiveSettings is used to configure Pubsub pull subscription.
 	ReceiveSettings *pubsub.ReceiveSettings
 
+	// MessageOrdering controls if the messages are published and delivered
+	// in order of their ordering key, mapped from the partitionkey extension.
+	MessageOrdering bool
+
 	// AllowCreateTopic controls if the transport can create a topic if it does
 	// not exist.
 	AllowCreateTopic bool
@@ -49,13 +54,20 @@ type Protocol struct {
 	connectionsBySubscription map[string]*internal.Connection
 	connectionsByTopic        map[string]*internal.Connection
 
-	incoming chan pubsub.Message
+	incoming chan incomingMessage
+}
+
+// incomingMessage is a received message with the ProtocolContext of its
+// subscription.
+type incomingMessage struct {
+	msg  pubsub.Message
+	pctx pscontext.ProtocolContext
 }
 
 // New creates a new pubsub transport.
 func New(ctx context.Context, opts ...Option) (*Protocol, error) {
 	t := &Protocol{}
-	t.incoming = make(chan pubsub.Message)
+	t.incoming = make(chan incomingMessage)
 	if err := t.applyOptions(opts...); err != nil {
 		return nil, err
 	}
@@ -140,6 +152,7 @@ func (t *Protocol) getOrCreateConnection(ctx context.Context, topic, subscriptio
 		AllowCreateSubscription: t.AllowCreateSubscription,
 		AllowCreateTopic:        t.AllowCreateTopic,
 		ReceiveSettings:         t.ReceiveSettings,
+		MessageOrdering:         t.MessageOrdering,
 		Client:                  t.client,
 		ProjectID:               t.projectID,
 		TopicID:                 topic,
@@ -164,7 +177,8 @@ func (t *Protocol) Receive(ctx context.Context) (binding.Message, error) {
 			return nil, io.EOF
 		}
 
-		msg := NewMessage(&m)
+		msg := NewMessage(&m.msg)
+		msg.protocolContext = &m.pctx
 		return msg, nil
 	case <-ctx.Done():
 		return nil, io.EOF
@@ -172,17 +186,17 @@ func (t *Protocol) Receive(ctx context.Context) (binding.Message, error) {
 }
 
 func (t *Protocol) startSubscriber(ctx context.Context, sub subscriptionWithTopic) error {
-	logger := cecontext.LoggerFrom(ctx)
-	logger.Infof("starting subscriber for Topic %q, Subscription %q", sub.topicID, sub.subscriptionID)
+	logger := cecontext.StructuredLoggerFrom(ctx)
+	logger.Infow("starting subscriber", "topic", sub.topicID, "subscription", sub.subscriptionID)
 	conn := t.getOrCreateConnection(ctx, sub.topicID, sub.subscriptionID)
 
-	logger.Info("conn is", conn)
+	logger.Debugw("conn is", "conn", conn)
 	if conn == nil {
 		return fmt.Errorf("failed to find connection for Topic: %q, Subscription: %q", sub.topicID, sub.subscriptionID)
 	}
 	// Ok, ready to start pulling.
 	return conn.Receive(ctx, func(ctx context.Context, m *pubsub.Message) {
-		t.incoming <- *m
+		t.incoming <- incomingMessage{msg: *m, pctx: pscontext.ProtocolContextFrom(ctx)}
 	})
 }
 
@@ -0,0 +1,38 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/google/go-cmp/cmp"
+
+	pscontext "github.com/cloudevents/sdk-go/protocol/pubsub/v2/context"
+	"github.com/cloudevents/sdk-go/v2/binding"
+)
+
+func TestReceive_ProtocolContext(t *testing.T) {
+	want := pscontext.ProtocolContext{
+		ID:           "testid",
+		PublishTime:  time.Unix(1600000000, 0),
+		Project:      "project",
+		Topic:        "topic",
+		Subscription: "subscription",
+		Method:       "pull",
+	}
+	p := &Protocol{incoming: make(chan incomingMessage, 1)}
+	p.incoming <- incomingMessage{msg: pubsub.Message{ID: "testid"}, pctx: want}
+	m, err := p.Receive(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The client wraps the received messages, e.g. to record the metrics
+	for _, msg := range []binding.Message{m, binding.WithFinish(m, nil)} {
+		got := pscontext.ProtocolContextFrom(binding.WithProtocolContext(context.TODO(), msg))
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected protocol context (-want, +got) = %v", diff)
+		}
+	}
+}
@@ -9,15 +9,37 @@ import (
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/format"
 	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/types"
 )
 
 // WritePubSubMessage fills the provided pubsubMessage with the message m.
 // Using context you can tweak the encoding processing (more details on binding.Write documentation).
+// The partitionkey extension, if set, is mapped to the ordering key of the message.
+// The trace context of the span in ctx, if any, is written in the W3C traceparent
+// and tracestate attributes.
 func WritePubSubMessage(ctx context.Context, m binding.Message, pubsubMessage *pubsub.Message, transformers ...binding.Transformer) error {
 	structuredWriter := (*pubsubMessagePublisher)(pubsubMessage)
 	binaryWriter := (*pubsubMessagePublisher)(pubsubMessage)
 
+	var orderingKey string
+	if len(transformers) == 0 && m.ReadEncoding() == binding.EncodingStructured {
+		// A transformer would prevent writing the structured message as is:
+		// read the partitionkey from the metadata of the message, if any
+		if r, ok := binding.UnwrapMessage(m).(binding.MessageMetadataReader); ok {
+			var err error
+			if orderingKey, err = extensions.ReadPartitionKey(r); err != nil {
+				return err
+			}
+		}
+	} else {
+		transformers = append(transformers, binding.TransformerFunc(func(r binding.MessageMetadataReader, w binding.MessageMetadataWriter) error {
+			var err error
+			orderingKey, err = extensions.ReadPartitionKey(r)
+			return err
+		}))
+	}
+
 	_, err := binding.Write(
 		ctx,
 		m,
@@ -25,6 +47,16 @@ func WritePubSubMessage(ctx context.Context, m binding.Message, pubsubMessage *p
 		binaryWriter,
 		transformers...,
 	)
+	pubsubMessage.OrderingKey = orderingKey
+	if tc, ok := extensions.TraceContextFromContext(ctx); ok && err == nil {
+		if pubsubMessage.Attributes == nil {
+			pubsubMessage.Attributes = make(map[string]string)
+		}
+		pubsubMessage.Attributes[extensions.TraceParentHeader] = tc.TraceParent
+		if tc.TraceState != "" {
+			pubsubMessage.Attributes[extensions.TraceStateHeader] = tc.TraceState
+		}
+	}
 	return err
 }
 
@@ -0,0 +1,50 @@
+package stan
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/stan.go"
+)
+
+// ProtocolContext holds the NATS Streaming metadata of a received message.
+type ProtocolContext struct {
+	Subject         string
+	Sequence        uint64
+	Timestamp       time.Time
+	Redelivered     bool
+	RedeliveryCount uint32
+}
+
+// NewProtocolContext creates a new ProtocolContext from a stan.Msg.
+func NewProtocolContext(msg *stan.Msg) ProtocolContext {
+	if msg == nil {
+		return ProtocolContext{}
+	}
+	return ProtocolContext{
+		Subject:         msg.Subject,
+		Sequence:        msg.Sequence,
+		Timestamp:       time.Unix(0, msg.Timestamp),
+		Redelivered:     msg.Redelivered,
+		RedeliveryCount: msg.RedeliveryCount,
+	}
+}
+
+// Opaque key type used to store ProtocolContext
+type protocolContextKeyType struct{}
+
+var protocolContextKey = protocolContextKeyType{}
+
+// WithProtocolContext returns a context with the given ProtocolContext into the provided context object.
+func WithProtocolContext(ctx context.Context, pctx ProtocolContext) context.Context {
+	return context.WithValue(ctx, protocolContextKey, pctx)
+}
+
+// ProtocolContextFrom pulls a ProtocolContext out of a context, or returns a
+// zero ProtocolContext.
+func ProtocolContextFrom(ctx context.Context) ProtocolContext {
+	if pctx, ok := ctx.Value(protocolContextKey).(ProtocolContext); ok {
+		return pctx
+	}
+	return ProtocolContext{}
+}
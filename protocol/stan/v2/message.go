@@ -40,6 +40,7 @@ func (m *Message) applyOptions(opts ...MessageOption) error {
 }
 
 var _ binding.Message = (*Message)(nil)
+var _ binding.ProtocolContextMessage = (*Message)(nil)
 
 func (m *Message) ReadEncoding() binding.Encoding {
 	return binding.EncodingStructured
@@ -53,6 +54,12 @@ func (m *Message) ReadBinary(context.Context, binding.BinaryWriter) error {
 	return binding.ErrNotBinary
 }
 
+// WithProtocolContext implements binding.ProtocolContextMessage, attaching the
+// ProtocolContext of the received message.
+func (m *Message) WithProtocolContext(ctx context.Context) context.Context {
+	return WithProtocolContext(ctx, NewProtocolContext(m.Msg))
+}
+
 func (m *Message) Finish(err error) error {
 	if !m.manualAcks {
 		return err
@@ -0,0 +1,45 @@
+package stan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/stan.go"
+	"github.com/nats-io/stan.go/pb"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+)
+
+func TestReceiver_ProtocolContext(t *testing.T) {
+	r, err := NewReceiver()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	timestamp := time.Unix(1600000000, 0)
+	go r.MsgHandler(&stan.Msg{MsgProto: pb.MsgProto{
+		Subject:         "subject",
+		Sequence:        42,
+		Timestamp:       timestamp.UnixNano(),
+		Redelivered:     true,
+		RedeliveryCount: 2,
+	}})
+	m, err := r.Receive(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := ProtocolContext{
+		Subject:         "subject",
+		Sequence:        42,
+		Timestamp:       timestamp,
+		Redelivered:     true,
+		RedeliveryCount: 2,
+	}
+	// The client wraps the received messages, e.g. to record the metrics
+	for _, msg := range []binding.Message{m, binding.WithFinish(m, nil)} {
+		if got := ProtocolContextFrom(binding.WithProtocolContext(context.TODO(), msg)); got != want {
+			t.Errorf("unexpected protocol context. got: %v, want: %v", got, want)
+		}
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/cloudevents/sdk-go/test/benchmark
 
-go 1.14
+go 1.17
 
 replace github.com/cloudevents/sdk-go/v2 => ../../v2
 
@@ -17,7 +17,7 @@ replace github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2 => ../../protocol
 require (
 	contrib.go.opencensus.io/exporter/prometheus v0.1.0
 	github.com/Azure/go-amqp v0.12.7
-	github.com/Shopify/sarama v1.25.0
+	github.com/Shopify/sarama v1.38.1
 	github.com/cloudevents/sdk-go v1.2.0
 	github.com/cloudevents/sdk-go/protocol/amqp/v2 v2.0.0
 	github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2 v2.0.0
@@ -25,10 +25,19 @@ require (
 	github.com/cloudevents/sdk-go/protocol/pubsub/v2 v2.0.0
 	github.com/cloudevents/sdk-go/protocol/stan/v2 v2.0.0
 	github.com/cloudevents/sdk-go/v2 v2.0.0
-	github.com/google/go-cmp v0.4.1
+	github.com/frankban/quicktest v1.4.1 // indirect
+	github.com/google/go-cmp v0.5.8
 	github.com/google/uuid v1.1.1
-	github.com/gorilla/mux v1.7.3
+	github.com/gorilla/mux v1.8.0
 	github.com/kelseyhightower/envconfig v1.4.0
-	github.com/stretchr/testify v1.5.1
-	go.opencensus.io v0.22.3
+	github.com/pierrec/lz4 v2.2.6+incompatible // indirect
+	github.com/stretchr/testify v1.8.1
+	github.com/xdg/scram v0.0.0-20180814205039-7eeb5667e42c // indirect
+	github.com/xdg/stringprep v1.0.0 // indirect
+	go.opencensus.io v0.22.4
+	gopkg.in/jcmturner/aescts.v1 v1.0.1 // indirect
+	gopkg.in/jcmturner/dnsutils.v1 v1.0.1 // indirect
+	gopkg.in/jcmturner/goidentity.v3 v3.0.0 // indirect
+	gopkg.in/jcmturner/gokrb5.v7 v7.2.3 // indirect
+	gopkg.in/jcmturner/rpc.v1 v1.1.0 // indirect
 )
@@ -78,6 +78,26 @@ func TestSendEventReceiveBinary(t *testing.T) {
 	})
 }
 
+func TestSendEventExactlyOnce(t *testing.T) {
+	c, ss, a := testClient(t)
+	defer c.Close()
+	ar, err := ss.NewReceiver(amqp.LinkSourceAddress(a), amqp.LinkReceiverSettle(amqp.ModeSecond))
+	require.NoError(t, err)
+	as, err := ss.NewSender(amqp.LinkTargetAddress(a))
+	require.NoError(t, err)
+	s, r := protocolamqp.NewSender(as), protocolamqp.NewReceiver(ar)
+
+	eventIn := ConvertEventExtensionsToString(t, FullEvent())
+	require.NoError(t, s.Send(context.Background(), (*binding.EventMessage)(&eventIn)))
+	out, err := r.Receive(context.Background())
+	require.NoError(t, err)
+	_, ok := out.(binding.ExactlyOnceMessage)
+	require.True(t, ok)
+	require.NoError(t, binding.SettleExactlyOnce(context.Background(), out))
+	require.NoError(t, out.Finish(nil))
+	AssertEventEquals(t, eventIn, ConvertEventExtensionsToString(t, MustToEvent(t, context.Background(), out)))
+}
+
 // Some test require an AMQP broker or router. If the connection fails
 // the tests are skipped. The env variable TEST_AMQP_URL can be set to the
 // test URL, otherwise the default is "/test"
@@ -5,9 +5,11 @@ import (
 	"github.com/nats-io/nats.go"
 	"os"
 	"testing"
+	"time"
 
 	ce_nats "github.com/cloudevents/sdk-go/protocol/nats/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/client"
 	"github.com/cloudevents/sdk-go/v2/event"
 	bindings "github.com/cloudevents/sdk-go/v2/protocol"
 	"github.com/cloudevents/sdk-go/v2/protocol/test"
@@ -110,3 +112,37 @@ func BenchmarkSendReceive(b *testing.B) {
 	defer c() // Cleanup
 	test.BenchmarkSendReceive(b, s, r)
 }
+
+func TestRequestResponse(t *testing.T) {
+	conn := testConn(t)
+	defer conn.Close()
+
+	subject := "test-ce-client-" + uuid.New().String()
+	p, err := ce_nats.NewProtocolFromConn(conn, subject, subject, ce_nats.WithSenderOptions(ce_nats.WithRequestTimeout(time.Second)))
+	require.NoError(t, err)
+	c, err := client.New(p)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = c.StartReceiver(ctx, func(e event.Event) *event.Event {
+			resp := event.New()
+			resp.SetID("resp-" + e.ID())
+			resp.SetType("response")
+			resp.SetSource("nats-test")
+			return &resp
+		})
+	}()
+
+	req := event.New()
+	req.SetID("1")
+	req.SetType("request")
+	req.SetSource("nats-test")
+	var resp *event.Event
+	require.Eventually(t, func() bool {
+		resp, err = c.Request(ctx, req)
+		return err == nil && resp != nil
+	}, 10*time.Second, 100*time.Millisecond)
+	require.Equal(t, "resp-1", resp.ID())
+}
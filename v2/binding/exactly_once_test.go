@@ -0,0 +1,28 @@
+package binding_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	bindingtest "github.com/cloudevents/sdk-go/v2/binding/test"
+	"github.com/cloudevents/sdk-go/v2/test"
+)
+
+func TestSettleExactlyOnce(t *testing.T) {
+	// Not an ExactlyOnceMessage
+	require.NoError(t, binding.SettleExactlyOnce(context.TODO(), test.FullMessage()))
+
+	m := &bindingtest.MockExactlyOnceMessage{Message: test.FullMessage()}
+	wrapped := binding.WithFinish(m, func(error) {})
+	require.NoError(t, binding.SettleExactlyOnce(context.TODO(), wrapped))
+	require.NoError(t, wrapped.Finish(nil))
+	require.Equal(t, []string{"received", "finish"}, m.Events())
+
+	settleErr := errors.New("transfer failed")
+	m = &bindingtest.MockExactlyOnceMessage{Message: test.FullMessage(), SettleErr: settleErr}
+	require.Equal(t, settleErr, binding.SettleExactlyOnce(context.TODO(), m))
+}
@@ -118,6 +118,41 @@ type ExactlyOnceMessage interface {
 	Received(settle func(error))
 }
 
+// SettleExactlyOnce drives the QoS 2 flow of message, or of the first Message
+// wrapped by message implementing ExactlyOnceMessage: it invokes Received and
+// waits the settlement, until ctx is done. Invoke it when the message is
+// delivered, e.g. acknowledged by the peer of a forwarding Sender, and before
+// Finish.
+// It returns nil if there is no ExactlyOnceMessage.
+func SettleExactlyOnce(ctx context.Context, message Message) error {
+	var eo ExactlyOnceMessage
+	for m := message; m != nil; {
+		var ok bool
+		if eo, ok = m.(ExactlyOnceMessage); ok {
+			break
+		}
+		mw, ok := m.(MessageWrapper)
+		if !ok {
+			return nil
+		}
+		m = mw.GetWrappedMessage()
+	}
+	if eo == nil {
+		return nil
+	}
+
+	settled := make(chan error, 1)
+	eo.Received(func(err error) {
+		settled <- err
+	})
+	select {
+	case err := <-settled:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // MessageWrapper interface is used to walk through a decorated Message and unwrap it.
 type MessageWrapper interface {
 	Message
@@ -139,3 +174,30 @@ func UnwrapMessage(message Message) Message {
 	}
 	return m
 }
+
+// ProtocolContextMessage is implemented by received Messages carrying
+// protocol metadata, e.g. the headers of an HTTP request or the offset of a
+// Kafka message. The protocol packages provide the accessors of the metadata
+// attached to the context.
+type ProtocolContextMessage interface {
+	// WithProtocolContext returns a context with the protocol metadata of the
+	// message attached.
+	WithProtocolContext(ctx context.Context) context.Context
+}
+
+// WithProtocolContext returns ctx with the protocol metadata of message, or of
+// the first Message wrapped by message carrying it, attached.
+// The client uses it to pass the protocol metadata to the receiver function.
+func WithProtocolContext(ctx context.Context, message Message) context.Context {
+	for m := message; m != nil; {
+		if pcm, ok := m.(ProtocolContextMessage); ok {
+			return pcm.WithProtocolContext(ctx)
+		}
+		mw, ok := m.(MessageWrapper)
+		if !ok {
+			break
+		}
+		m = mw.GetWrappedMessage()
+	}
+	return ctx
+}
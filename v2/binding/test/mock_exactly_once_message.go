@@ -0,0 +1,60 @@
+package test
+
+import (
+	"sync"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+)
+
+// MockExactlyOnceMessage wraps a Message as a binding.ExactlyOnceMessage,
+// recording the calls of Received and Finish. The wrapped Message must
+// implement binding.MessageMetadataReader.
+type MockExactlyOnceMessage struct {
+	binding.Message
+
+	// SettleErr is passed to the settle function of Received
+	SettleErr error
+
+	lock   sync.Mutex
+	events []string
+}
+
+var _ binding.ExactlyOnceMessage = (*MockExactlyOnceMessage)(nil)
+var _ binding.MessageWrapper = (*MockExactlyOnceMessage)(nil)
+
+func (m *MockExactlyOnceMessage) GetAttribute(k spec.Kind) (spec.Attribute, interface{}) {
+	return m.Message.(binding.MessageMetadataReader).GetAttribute(k)
+}
+
+func (m *MockExactlyOnceMessage) GetExtension(name string) interface{} {
+	return m.Message.(binding.MessageMetadataReader).GetExtension(name)
+}
+
+func (m *MockExactlyOnceMessage) GetWrappedMessage() binding.Message {
+	return m.Message
+}
+
+// Received implements binding.ExactlyOnceMessage.Received, settling with SettleErr.
+func (m *MockExactlyOnceMessage) Received(settle func(error)) {
+	m.record("received")
+	settle(m.SettleErr)
+}
+
+func (m *MockExactlyOnceMessage) Finish(err error) error {
+	m.record("finish")
+	return m.Message.Finish(err)
+}
+
+// Events returns the sequence of the calls, "received" and "finish".
+func (m *MockExactlyOnceMessage) Events() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]string(nil), m.events...)
+}
+
+func (m *MockExactlyOnceMessage) record(event string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.events = append(m.events, event)
+}
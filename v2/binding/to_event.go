@@ -89,6 +89,9 @@ func (b *messageToEventBuilder) SetData(data io.Reader) error {
 	}
 	if buf.Len() > 0 {
 		b.DataEncoded = buf.Bytes()
+	} else {
+		// A transformer may remove the data
+		b.DataEncoded = nil
 	}
 	return nil
 }
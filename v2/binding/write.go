@@ -83,7 +83,7 @@ func Write(
 
 	message = (*EventMessage)(e)
 
-	if GetOrDefaultFromCtx(ctx, preferredEventEncoding, EncodingBinary).(Encoding) == EncodingStructured {
+	if PreferredEventEncoding(ctx) == EncodingStructured {
 		if structuredWriter != nil {
 			return EncodingStructured, message.ReadStructured(ctx, structuredWriter)
 		}
@@ -117,6 +117,12 @@ func WithPreferredEventEncoding(ctx context.Context, enc Encoding) context.Conte
 	return context.WithValue(ctx, preferredEventEncoding, enc)
 }
 
+// PreferredEventEncoding returns the preferred encoding from event to message
+// defined in ctx, EncodingBinary by default.
+func PreferredEventEncoding(ctx context.Context) Encoding {
+	return GetOrDefaultFromCtx(ctx, preferredEventEncoding, EncodingBinary).(Encoding)
+}
+
 // WithForceStructured forces structured encoding during the encoding process
 func WithForceStructured(ctx context.Context) context.Context {
 	return context.WithValue(context.WithValue(ctx, preferredEventEncoding, EncodingStructured), skipDirectBinaryEncoding, true)
@@ -8,11 +8,11 @@ import (
 	"runtime"
 	"sync"
 
-	"go.uber.org/zap"
-
 	"github.com/cloudevents/sdk-go/v2/binding"
 	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/event/registry"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 )
 
@@ -42,6 +42,11 @@ type Client interface {
 	// * func(event.Event) (*event.Event, protocol.Result)
 	// * func(context.Context, event.Event) *event.Event
 	// * func(context.Context, event.Event) (*event.Event, protocol.Result)
+	// Typed receivers are valid too, taking the decoded event data as third
+	// parameter, e.g. func(context.Context, event.Event, *OrderCreated).
+	// The data parameter can be a pointer to a struct or an interface, in which
+	// case the struct registered for the event type in the registry.Registry
+	// provided with WithEventRegistry is used.
 	StartReceiver(ctx context.Context, fn interface{}) error
 }
 
@@ -50,7 +55,8 @@ type Client interface {
 func New(obj interface{}, opts ...Option) (Client, error) {
 	c := &ceClient{
 		// Running runtime.GOMAXPROCS(0) doesn't update the value, just returns the current one
-		pollGoroutines: runtime.GOMAXPROCS(0),
+		pollGoroutines:       runtime.GOMAXPROCS(0),
+		observabilityService: noopObservabilityService{},
 	}
 
 	if p, ok := obj.(protocol.Sender); ok {
@@ -87,7 +93,18 @@ type ceClient struct {
 	invoker                   Invoker
 	receiverMu                sync.Mutex
 	eventDefaulterFns         []EventDefaulter
+	eventValidatorFns         []EventValidator
+	inboundEventValidatorFns  []EventValidator
+	validationLevel           event.ValidationLevel
+	outboundTransformers      binding.Transformers
+	inboundTransformers       binding.Transformers
 	pollGoroutines            int
+	registry                  *registry.Registry
+	sampler                   *extensions.Sampler
+	tracePropagation          bool
+	observabilityService      ObservabilityService
+	replySender               protocol.Sender
+	defaultReplyTo            string
 }
 
 func (c *ceClient) applyOptions(opts ...Option) error {
@@ -114,11 +131,29 @@ func (c *ceClient) Send(ctx context.Context, e event.Event) protocol.Result {
 		}
 	}
 
-	if err := e.Validate(); err != nil {
+	if c.sampler != nil {
+		// Sample after the defaulters, as the decision depends on the id
+		e = e.Clone()
+		if !c.sampler.Sample(&e) {
+			return nil
+		}
+	}
+
+	if err := c.validate(ctx, e); err != nil {
 		return err
 	}
 
-	return c.sender.Send(ctx, (*binding.EventMessage)(&e))
+	ctx, cb := c.observabilityService.RecordSendingEvent(ctx, e)
+	if c.tracePropagation {
+		e = withTraceContext(ctx, e)
+	}
+	if len(c.outboundTransformers) > 0 && c.sampler == nil {
+		// The transformers modify the event in place
+		e = e.Clone()
+	}
+	err := c.sender.Send(ctx, (*binding.EventMessage)(&e), c.outboundTransformers...)
+	cb(err)
+	return err
 }
 
 func (c *ceClient) Request(ctx context.Context, e event.Event) (*event.Event, protocol.Result) {
@@ -135,17 +170,31 @@ func (c *ceClient) Request(ctx context.Context, e event.Event) (*event.Event, pr
 		}
 	}
 
-	if err := e.Validate(); err != nil {
+	if err := c.validate(ctx, e); err != nil {
 		return nil, err
 	}
 
+	ctx, cb := c.observabilityService.RecordRequestEvent(ctx, e)
+	if c.tracePropagation {
+		e = withTraceContext(ctx, e)
+	}
+	if len(c.outboundTransformers) > 0 {
+		// The transformers modify the event in place
+		e = e.Clone()
+	}
+	resp, err := c.request(ctx, e)
+	cb(err, resp)
+	return resp, err
+}
+
+func (c *ceClient) request(ctx context.Context, e event.Event) (*event.Event, protocol.Result) {
 	// If provided a requester, use it to do request/response.
 	var resp *event.Event
-	msg, err := c.requester.Request(ctx, (*binding.EventMessage)(&e))
+	msg, err := c.requester.Request(ctx, (*binding.EventMessage)(&e), c.outboundTransformers...)
 	if msg != nil {
 		defer func() {
 			if err := msg.Finish(err); err != nil {
-				cecontext.LoggerFrom(ctx).Warnw("failed calling message.Finish", zap.Error(err))
+				cecontext.StructuredLoggerFrom(ctx).Warnw("failed calling message.Finish", "error", err)
 			}
 		}()
 	}
@@ -154,8 +203,8 @@ func (c *ceClient) Request(ctx context.Context, e event.Event) (*event.Event, pr
 	}
 
 	// try to turn msg into an event, it might not work and that is ok.
-	if rs, rserr := binding.ToEvent(ctx, msg); rserr != nil {
-		cecontext.LoggerFrom(ctx).Debugw("response: failed calling ToEvent", zap.Error(rserr), zap.Any("resp", msg))
+	if rs, rserr := binding.ToEvent(ctx, msg, c.inboundTransformers...); rserr != nil {
+		cecontext.StructuredLoggerFrom(ctx).Debugw("response: failed calling ToEvent", "error", rserr, "resp", msg)
 		// If the protocol returns no error, it is an ACK on the request, but we had
 		// issues turning the response into an event, so make an ACK Result and pass
 		// down the ToEvent error as well.
@@ -167,6 +216,50 @@ func (c *ceClient) Request(ctx context.Context, e event.Event) (*event.Event, pr
 	return resp, err
 }
 
+// withTraceContext sets the distributed tracing extension of e, if missing,
+// to the trace context of ctx.
+func withTraceContext(ctx context.Context, e event.Event) event.Event {
+	if _, ok := extensions.GetDistributedTracingExtension(e); ok {
+		return e
+	}
+	if tc, ok := extensions.TraceContextFromContext(ctx); ok {
+		e.Context = e.Context.Clone()
+		tc.AddTracingAttributes(&e)
+	}
+	return e
+}
+
+// invokerConfig returns the configuration of the receiveInvoker built from
+// the client options.
+func (c *ceClient) invokerConfig() invokerConfig {
+	return invokerConfig{
+		registry:             c.registry,
+		eventDefaulterFns:    c.eventDefaulterFns,
+		eventValidatorFns:    append(append([]EventValidator(nil), c.eventValidatorFns...), c.inboundEventValidatorFns...),
+		validationLevel:      c.validationLevel,
+		outboundTransformers: c.outboundTransformers,
+		inboundTransformers:  c.inboundTransformers,
+		tracePropagation:     c.tracePropagation,
+		observabilityService: c.observabilityService,
+		replySender:          c.replySender,
+		defaultReplyTo:       c.defaultReplyTo,
+	}
+}
+
+// validate performs the spec based validation of e with the configured
+// validation level, followed by the configured event validators.
+func (c *ceClient) validate(ctx context.Context, e event.Event) error {
+	if err := e.ValidateWithLevel(c.validationLevel); err != nil {
+		return err
+	}
+	for _, fn := range c.eventValidatorFns {
+		if err := fn(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // StartReceiver sets up the given fn to handle Receive.
 // See Client.StartReceiver for details. This is a blocking call.
 func (c *ceClient) StartReceiver(ctx context.Context, fn interface{}) error {
@@ -180,14 +273,14 @@ func (c *ceClient) StartReceiver(ctx context.Context, fn interface{}) error {
 		return fmt.Errorf("client already has a receiver")
 	}
 
-	invoker, err := newReceiveInvoker(fn, c.eventDefaulterFns...) // TODO: this will have to pick between a observed invoker or not.
+	invoker, err := newReceiveInvoker(fn, c.invokerConfig()) // TODO: this will have to pick between a observed invoker or not.
 	if err != nil {
 		return err
 	}
 	if invoker.IsReceiver() && c.receiver == nil {
 		return fmt.Errorf("mismatched receiver callback without protocol.Receiver supported by protocol")
 	}
-	if invoker.IsResponder() && c.responder == nil {
+	if invoker.IsResponder() && c.responder == nil && c.replySender == nil {
 		return fmt.Errorf("mismatched receiver callback without protocol.Responder supported by protocol")
 	}
 	c.invoker = invoker
@@ -211,7 +304,7 @@ func (c *ceClient) StartReceiver(ctx context.Context, fn interface{}) error {
 				var respFn protocol.ResponseFn
 				var err error
 
-				if c.responder != nil {
+				if c.responder != nil && (c.replySender == nil || c.receiver == nil) {
 					msg, respFn, err = c.responder.Respond(ctx)
 				} else if c.receiver != nil {
 					msg, err = c.receiver.Receive(ctx)
@@ -223,7 +316,7 @@ func (c *ceClient) StartReceiver(ctx context.Context, fn interface{}) error {
 				}
 
 				if err != nil {
-					cecontext.LoggerFrom(ctx).Warnf("Error while receiving a message: %s", err)
+					cecontext.StructuredLoggerFrom(ctx).Warnw("Error while receiving a message", "error", err)
 					continue
 				}
 
@@ -231,7 +324,7 @@ func (c *ceClient) StartReceiver(ctx context.Context, fn interface{}) error {
 				wg.Add(1)
 				go func() {
 					if err := c.invoker.Invoke(ctx, msg, respFn); err != nil {
-						cecontext.LoggerFrom(ctx).Warnf("Error while handling a message: %s", err)
+						cecontext.StructuredLoggerFrom(ctx).Warnw("Error while handling a message", "error", err)
 					}
 					wg.Done()
 				}()
@@ -51,7 +51,9 @@ func (c *obsClient) Send(ctx context.Context, e event.Event) protocol.Result {
 		span.AddAttributes(EventTraceAttributes(&e)...)
 	}
 
-	if c.addTracing {
+	// Preserve the trace context of the producer of events already traced,
+	// e.g. by a bridge: the protocols propagate the current span in native headers.
+	if _, traced := extensions.GetDistributedTracingExtension(e); c.addTracing && !traced {
 		e.Context = e.Context.Clone()
 		extensions.FromSpanContext(span.SpanContext()).AddTracingAttributes(&e)
 	}
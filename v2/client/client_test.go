@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -17,9 +18,15 @@ import (
 	"github.com/lightstep/tracecontext.go/traceparent"
 	"go.opencensus.io/trace"
 
+	"github.com/cloudevents/sdk-go/v2/binding"
+	bindingtest "github.com/cloudevents/sdk-go/v2/binding/test"
 	"github.com/cloudevents/sdk-go/v2/client"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+	"github.com/cloudevents/sdk-go/v2/extensions/dataref"
 	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/cloudevents/sdk-go/v2/protocol/gochan"
 	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 	"github.com/cloudevents/sdk-go/v2/types"
 )
@@ -641,3 +648,309 @@ func isImportantHeader(h string) bool {
 	}
 	return true
 }
+
+func TestClientSend_Sampling(t *testing.T) {
+	messages := make(chan binding.Message, 200)
+	c, err := client.New(gochan.Sender(messages), client.WithSampling(extensions.NewSampler(4, map[string]int32{"unsampled": 1})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	send := func(id, eventType string) {
+		e := event.New()
+		e.SetID(id)
+		e.SetType(eventType)
+		e.SetSource("example/uri")
+		if result := c.Send(context.Background(), e); !protocol.IsACK(result) {
+			t.Fatalf("unexpected result: %v", result)
+		}
+		if _, ok := e.Extensions()[extensions.SampledRateExtension]; ok {
+			t.Errorf("the event of the caller was modified")
+		}
+	}
+	for i := 0; i < 100; i++ {
+		send(fmt.Sprintf("id-%d", i), "sampled")
+		send(fmt.Sprintf("id-%d", i), "unsampled")
+	}
+	close(messages)
+
+	counts := map[string]int{}
+	for m := range messages {
+		e, err := binding.ToEvent(context.Background(), m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rate, ok := extensions.GetSampledRate(*e)
+		if e.Type() == "sampled" && (!ok || rate != 4) {
+			t.Errorf("unexpected sampledrate %d for %s", rate, e.ID())
+		}
+		if e.Type() == "unsampled" && ok {
+			t.Errorf("unexpected sampledrate %d for %s", rate, e.ID())
+		}
+		counts[e.Type()]++
+	}
+	if counts["unsampled"] != 100 {
+		t.Errorf("unexpected count of unsampled events: %d", counts["unsampled"])
+	}
+	if counts["sampled"] < 10 || counts["sampled"] > 45 {
+		t.Errorf("unexpected count of sampled events: %d", counts["sampled"])
+	}
+}
+
+func TestClientSend_OutboundTransformers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dataref")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := dataref.NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender := make(transformingSender, 1)
+	c, err := client.New(sender, client.WithOutboundTransformers(dataref.NewOffloader(store, 1).Transformer(context.Background())))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := event.New()
+	e.SetID("abc-123")
+	e.SetType("unit.test.client.sent")
+	e.SetSource("/unit/test/client")
+	_ = e.SetData(event.ApplicationJSON, map[string]string{"hello": "world"})
+	want := e.Clone()
+
+	if result := c.Send(context.Background(), e); !protocol.IsACK(result) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+	if diff := cmp.Diff(want, e); diff != "" {
+		t.Errorf("the event of the caller was modified (-want, +got) = %v", diff)
+	}
+	if _, ok := dataref.GetDataRef(*<-sender); !ok {
+		t.Errorf("the sent event has no dataref")
+	}
+}
+
+// transformingSender converts the sent messages to events applying the
+// transformers, like the protocol senders writing the messages.
+type transformingSender chan *event.Event
+
+func (s transformingSender) Send(ctx context.Context, m binding.Message, transformers ...binding.Transformer) error {
+	e, err := binding.ToEvent(ctx, m, transformers...)
+	if err == nil {
+		s <- e
+	}
+	return m.Finish(err)
+}
+
+func TestClientReceive_LoggerFields(t *testing.T) {
+	p := gochan.New()
+	c, err := client.New(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	ctx, cancel := context.WithCancel(cecontext.WithStructuredLogger(context.Background(),
+		cecontext.NewStdLogger(log.New(&buf, "", 0), cecontext.InfoLevel)))
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		_ = c.StartReceiver(ctx, func(ctx context.Context, e event.Event) {
+			cecontext.StructuredLoggerFrom(ctx).Infow("handled")
+			close(done)
+		})
+	}()
+
+	e := event.New()
+	e.SetID("ABC-123")
+	e.SetType("unit.test.client")
+	e.SetSource("example/uri")
+	if result := c.Send(context.Background(), e); !protocol.IsACK(result) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the event")
+	}
+
+	want := "INFO handled id=ABC-123 type=unit.test.client source=example/uri\n"
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected log, want %q, got %q", want, got)
+	}
+}
+
+type replySender struct {
+	err     error
+	replies chan replySent
+}
+
+type replySent struct {
+	topic string
+	event *event.Event
+}
+
+func (s *replySender) Send(ctx context.Context, m binding.Message, transformers ...binding.Transformer) error {
+	e, err := binding.ToEvent(ctx, m, transformers...)
+	if err != nil {
+		return err
+	}
+	s.replies <- replySent{topic: cecontext.TopicFrom(ctx), event: e}
+	return s.err
+}
+
+func TestClientReceive_ReplySender(t *testing.T) {
+	requests := make(chan binding.Message)
+	sender := &replySender{replies: make(chan replySent, 1)}
+	c, err := client.New(gochan.Receiver(requests), client.WithReplySender(sender, "default-replies"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = c.StartReceiver(ctx, func(e event.Event) *event.Event {
+			resp := event.New()
+			resp.SetID("resp-" + e.ID())
+			resp.SetType("unit.test.response")
+			resp.SetSource("example/uri")
+			return &resp
+		})
+	}()
+
+	request := func(id, replyTo string) (replySent, error) {
+		e := event.New()
+		e.SetID(id)
+		e.SetType("unit.test.request")
+		e.SetSource("example/uri")
+		extensions.SetReplyTo(&e, replyTo)
+		finished := make(chan error, 1)
+		requests <- binding.WithFinish(binding.ToMessage(&e), func(err error) { finished <- err })
+		select {
+		case err := <-finished:
+			select {
+			case reply := <-sender.replies:
+				return reply, err
+			default:
+				return replySent{}, err
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for the request")
+		}
+		return replySent{}, nil
+	}
+
+	reply, err := request("1", "replies")
+	if !protocol.IsACK(err) {
+		t.Errorf("unexpected result: %v", err)
+	}
+	if reply.topic != "replies" {
+		t.Errorf("unexpected reply topic %q", reply.topic)
+	}
+	if id, _ := extensions.GetCorrelationID(*reply.event); id != "1" {
+		t.Errorf("unexpected correlationid %q", id)
+	}
+
+	reply, _ = request("2", "")
+	if reply.topic != "default-replies" {
+		t.Errorf("unexpected reply topic %q", reply.topic)
+	}
+
+	sender.err = fmt.Errorf("unavailable")
+	if _, err = request("3", "replies"); !protocol.IsNACK(err) {
+		t.Errorf("expected NACK, got %v", err)
+	}
+}
+
+func TestClientReceive_ReplySenderNonEventMessage(t *testing.T) {
+	requests := make(chan binding.Message)
+	sender := &replySender{replies: make(chan replySent, 1)}
+	c, err := client.New(gochan.Receiver(requests), client.WithReplySender(sender, "default-replies"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = c.StartReceiver(ctx, func(context.Context) *event.Event {
+			resp := event.New()
+			resp.SetID("resp")
+			resp.SetType("unit.test.response")
+			resp.SetSource("example/uri")
+			return &resp
+		})
+	}()
+
+	finished := make(chan error, 1)
+	requests <- binding.WithFinish(bindingtest.UnknownMessage, func(err error) { finished <- err })
+	select {
+	case err := <-finished:
+		if !protocol.IsACK(err) {
+			t.Errorf("unexpected result: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the message to be finished")
+	}
+
+	select {
+	case reply := <-sender.replies:
+		if reply.topic != "default-replies" {
+			t.Errorf("unexpected reply topic %q", reply.topic)
+		}
+		if id, ok := extensions.GetCorrelationID(*reply.event); ok {
+			t.Errorf("unexpected correlationid %q", id)
+		}
+	default:
+		t.Error("expected a reply")
+	}
+}
+
+func TestClientReceive_ExactlyOnce(t *testing.T) {
+	requests := make(chan binding.Message)
+	c, err := client.New(gochan.Receiver(requests))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = c.StartReceiver(ctx, func(e event.Event) protocol.Result {
+			if e.ID() == "fail" {
+				return protocol.ResultNACK
+			}
+			return nil
+		})
+	}()
+
+	receive := func(id string) []string {
+		e := event.New()
+		e.SetID(id)
+		e.SetType("unit.test.client")
+		e.SetSource("example/uri")
+		finished := make(chan error, 1)
+		m := &bindingtest.MockExactlyOnceMessage{
+			Message: binding.WithFinish(binding.ToMessage(&e), func(err error) { finished <- err }),
+		}
+		requests <- m
+		select {
+		case <-finished:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for the message to be finished")
+		}
+		return m.Events()
+	}
+
+	// The processed message is settled before being finished
+	if diff := cmp.Diff([]string{"received", "finish"}, receive("ok")); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+	// The failed message is finished without settlement
+	if diff := cmp.Diff([]string{"finish"}, receive("fail")); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
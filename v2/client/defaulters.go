@@ -2,9 +2,11 @@ package client
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 
 	"github.com/google/uuid"
 )
@@ -50,3 +52,31 @@ func NewDefaultDataContentTypeIfNotSet(contentType string) EventDefaulter {
 		return event
 	}
 }
+
+// NewDefaultSequenceIfNotSet returns a defaulter that will inspect the provided
+// event and set the sequence extension if it is found to be empty. Sequences
+// are monotonically increasing per source, starting from 1, and formatted to
+// be lexicographically sortable.
+// The sequences are not persisted: they restart from 1 when the process
+// restarts, and a receiver tracking them, e.g. with extensions.SequenceTracker,
+// sees the events after the restart as duplicates. Give each process its own
+// source, or set the sequence extension from a persisted counter, when the
+// receivers track the sequences across restarts.
+func NewDefaultSequenceIfNotSet() EventDefaulter {
+	var mu sync.Mutex
+	last := make(map[string]uint64)
+	return func(ctx context.Context, event event.Event) event.Event {
+		if event.Context != nil {
+			if _, ok := event.Extensions()[extensions.SequenceExtension]; !ok {
+				mu.Lock()
+				last[event.Source()]++
+				seq := last[event.Source()]
+				mu.Unlock()
+
+				event.Context = event.Context.Clone()
+				extensions.SetSequence(&event, seq)
+			}
+		}
+		return event
+	}
+}
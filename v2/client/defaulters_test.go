@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 )
 
 var versions = []string{"0.3", "1.0"}
@@ -140,3 +141,38 @@ func TestNewDefaultDataContentTypeIfNotSet_set(t *testing.T) {
 		})
 	}
 }
+
+func TestNewDefaultSequenceIfNotSet(t *testing.T) {
+	fn := NewDefaultSequenceIfNotSet()
+	newEvent := func(source string) event.Event {
+		e := event.New()
+		e.SetSource(source)
+		return e
+	}
+
+	var last string
+	for i := 1; i <= 11; i++ {
+		got := fn(context.TODO(), newEvent("a"))
+		seq, ok := extensions.GetSequence(got)
+		if !ok || seq != uint64(i) {
+			t.Fatalf("unexpected sequence %v, want %d", got.Extensions()[extensions.SequenceExtension], i)
+		}
+		current := got.Extensions()[extensions.SequenceExtension].(string)
+		if current <= last {
+			t.Errorf("sequence %q is not lexicographically after %q", current, last)
+		}
+		last = current
+	}
+
+	// Sequences are per source
+	if seq, _ := extensions.GetSequence(fn(context.TODO(), newEvent("b"))); seq != 1 {
+		t.Errorf("unexpected sequence %d for a new source", seq)
+	}
+
+	// Sequences already set are preserved
+	e := newEvent("a")
+	extensions.SetSequence(&e, 100)
+	if seq, _ := extensions.GetSequence(fn(context.TODO(), e)); seq != 100 {
+		t.Errorf("failed to preserve sequence, got %d", seq)
+	}
+}
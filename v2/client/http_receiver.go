@@ -3,15 +3,24 @@ package client
 import (
 	"context"
 	cecontext "github.com/cloudevents/sdk-go/v2/context"
-	"go.uber.org/zap"
 	"net/http"
 	"sync"
 
 	thttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 )
 
-func NewHTTPReceiveHandler(ctx context.Context, p *thttp.Protocol, fn interface{}) (*EventReceiver, error) {
-	invoker, err := newReceiveInvoker(fn)
+// NewHTTPReceiveHandler returns an http.Handler invoking fn with the events
+// received by p. fn has the same signatures allowed by Client.StartReceiver.
+// The client options configuring the receiver, like WithEventRegistry,
+// WithEventValidator or WithInboundTransformers, apply to the handler too,
+// while the options configuring the outbound events are ignored.
+func NewHTTPReceiveHandler(ctx context.Context, p *thttp.Protocol, fn interface{}, opts ...Option) (*EventReceiver, error) {
+	c := &ceClient{observabilityService: noopObservabilityService{}}
+	if err := c.applyOptions(opts...); err != nil {
+		return nil, err
+	}
+
+	invoker, err := newReceiveInvoker(fn, c.invokerConfig())
 	if err != nil {
 		return nil, err
 	}
@@ -38,9 +47,9 @@ func (r *EventReceiver) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	ctx := req.Context()
 	msg, respFn, err := r.p.Respond(ctx)
 	if err != nil {
-		cecontext.LoggerFrom(context.TODO()).Debugw("failed to call Respond", zap.Error(err))
+		cecontext.StructuredLoggerFrom(ctx).Debugw("failed to call Respond", "error", err)
 	} else if err := r.invoker.Invoke(ctx, msg, respFn); err != nil {
-		cecontext.LoggerFrom(context.TODO()).Debugw("failed to call Invoke", zap.Error(err))
+		cecontext.StructuredLoggerFrom(ctx).Debugw("failed to call Invoke", "error", err)
 	}
 	// Block until ServeHTTP has returned
 	wg.Wait()
@@ -9,6 +9,8 @@ import (
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/client"
+	"github.com/cloudevents/sdk-go/v2/event/registry"
+	thttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 	"github.com/stretchr/testify/require"
 )
 
@@ -64,3 +66,91 @@ func TestEventReceiverServeHTTP_WithContext(t *testing.T) {
 	result := c.Send(ctx, event)
 	require.True(t, cloudevents.IsACK(result))
 }
+
+func TestEventReceiverServeHTTP_ProtocolContext(t *testing.T) {
+	received := make(chan thttp.ProtocolContext, 1)
+	eventReceiver := func(ctx context.Context) {
+		received <- thttp.ProtocolContextFrom(ctx)
+	}
+
+	p, err := cloudevents.NewHTTP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpHandler, err := client.NewHTTPReceiveHandler(context.Background(), p, eventReceiver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := cloudevents.NewDefaultClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(httpHandler)
+	defer ts.Close()
+
+	event := cloudevents.NewEvent()
+	event.SetSource("testSource")
+	event.SetType("testType")
+	ctx := cloudevents.ContextWithTarget(context.Background(), ts.URL+"/test?key=value")
+
+	result := c.Send(ctx, event)
+	require.True(t, cloudevents.IsACK(result))
+
+	pctx := <-received
+	require.Equal(t, http.MethodPost, pctx.Method)
+	require.Equal(t, "/test", pctx.URL.Path)
+	require.Equal(t, "value", pctx.URL.Query().Get("key"))
+	require.Equal(t, "testType", pctx.Header.Get("Ce-Type"))
+	require.NotEmpty(t, pctx.RemoteAddr)
+}
+
+func TestEventReceiverServeHTTP_WithOptions(t *testing.T) {
+	type orderCreated struct {
+		OrderID string `json:"orderId"`
+	}
+	reg := registry.New()
+	reg.MustRegister("com.example.order.created", orderCreated{})
+
+	received := make(chan *orderCreated, 1)
+	eventReceiver := func(ctx context.Context, e cloudevents.Event, data interface{}) {
+		received <- data.(*orderCreated)
+	}
+
+	p, err := cloudevents.NewHTTP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.NewHTTPReceiveHandler(context.Background(), p, eventReceiver); err == nil {
+		t.Fatal("expected an error without the event registry")
+	}
+	httpHandler, err := client.NewHTTPReceiveHandler(context.Background(), p, eventReceiver,
+		client.WithEventRegistry(reg),
+		client.WithEventValidator(func(ctx context.Context, e cloudevents.Event) error {
+			if e.Source() != "testSource" {
+				return errors.New("unexpected source")
+			}
+			return nil
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := cloudevents.NewDefaultClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(httpHandler)
+	defer ts.Close()
+	ctx := cloudevents.ContextWithTarget(context.Background(), ts.URL)
+
+	event := cloudevents.NewEvent()
+	event.SetSource("otherSource")
+	event.SetType("com.example.order.created")
+	_ = event.SetData(cloudevents.ApplicationJSON, orderCreated{OrderID: "42"})
+	require.True(t, cloudevents.IsNACK(c.Send(ctx, event)))
+
+	event.SetSource("testSource")
+	require.True(t, cloudevents.IsACK(c.Send(ctx, event)))
+	require.Equal(t, &orderCreated{OrderID: "42"}, <-received)
+}
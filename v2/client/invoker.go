@@ -3,10 +3,16 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"reflect"
+
+	"go.opencensus.io/trace"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
 	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/event/registry"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 )
 
@@ -18,9 +24,40 @@ type Invoker interface {
 
 var _ Invoker = (*receiveInvoker)(nil)
 
-func newReceiveInvoker(fn interface{}, fns ...EventDefaulter) (Invoker, error) {
+// invokerConfig holds the client configuration used by the receiveInvoker.
+type invokerConfig struct {
+	registry          *registry.Registry
+	eventDefaulterFns []EventDefaulter
+	eventValidatorFns []EventValidator
+	validationLevel   event.ValidationLevel
+	// outboundTransformers are applied to the response events.
+	outboundTransformers binding.Transformers
+	// inboundTransformers are applied converting the incoming messages to events.
+	inboundTransformers binding.Transformers
+	// tracePropagation enables the spans of the incoming events.
+	tracePropagation bool
+	// observabilityService records the incoming events.
+	observabilityService ObservabilityService
+	// replySender sends the response events, when set.
+	replySender    protocol.Sender
+	defaultReplyTo string
+}
+
+func newReceiveInvoker(fn interface{}, cfg invokerConfig) (Invoker, error) {
 	r := &receiveInvoker{
-		eventDefaulterFns: fns,
+		eventDefaulterFns:    cfg.eventDefaulterFns,
+		eventValidatorFns:    cfg.eventValidatorFns,
+		validationLevel:      cfg.validationLevel,
+		registry:             cfg.registry,
+		outboundTransformers: cfg.outboundTransformers,
+		inboundTransformers:  cfg.inboundTransformers,
+		tracePropagation:     cfg.tracePropagation,
+		observabilityService: cfg.observabilityService,
+		replySender:          cfg.replySender,
+		defaultReplyTo:       cfg.defaultReplyTo,
+	}
+	if r.observabilityService == nil {
+		r.observabilityService = noopObservabilityService{}
 	}
 
 	if fn, err := receiver(fn); err != nil {
@@ -29,44 +66,94 @@ func newReceiveInvoker(fn interface{}, fns ...EventDefaulter) (Invoker, error) {
 		r.fn = fn
 	}
 
+	if r.fn.hasDataIn && r.fn.dataType.Kind() == reflect.Interface && r.registry == nil {
+		return nil, fmt.Errorf("receiver with data parameter of type %s requires an event registry, use client.WithEventRegistry", r.fn.dataType)
+	}
+
 	return r, nil
 }
 
 type receiveInvoker struct {
-	fn                *receiverFn
-	eventDefaulterFns []EventDefaulter
+	fn                   *receiverFn
+	eventDefaulterFns    []EventDefaulter
+	eventValidatorFns    []EventValidator
+	validationLevel      event.ValidationLevel
+	registry             *registry.Registry
+	outboundTransformers binding.Transformers
+	inboundTransformers  binding.Transformers
+	tracePropagation     bool
+	observabilityService ObservabilityService
+	replySender          protocol.Sender
+	defaultReplyTo       string
 }
 
 func (r *receiveInvoker) Invoke(ctx context.Context, m binding.Message, respFn protocol.ResponseFn) (err error) {
 	defer func() {
+		// QoS 2: the processed message is settled before finishing it
+		if protocol.IsACK(err) {
+			if settleErr := binding.SettleExactlyOnce(ctx, m); settleErr != nil {
+				err = settleErr
+			}
+		}
 		err = m.Finish(err)
 	}()
 
 	var respMsg binding.Message
 	var result protocol.Result
 
-	e, eventErr := binding.ToEvent(ctx, m)
+	ctx = binding.WithProtocolContext(ctx, m)
+	for _, decorator := range r.observabilityService.InboundContextDecorators() {
+		ctx = decorator(ctx, m)
+	}
+
+	e, eventErr := binding.ToEvent(ctx, m, r.inboundTransformers...)
 	switch {
 	case eventErr != nil && r.fn.hasEventIn:
+		r.observabilityService.RecordReceivedMalformedEvent(ctx, eventErr)
 		return respFn(ctx, nil, protocol.NewReceipt(false, "failed to convert Message to Event: %w", eventErr))
 	case r.fn != nil:
+		if e != nil {
+			ctx = cecontext.WithLoggerFields(ctx, "id", e.ID(), "type", e.Type(), "source", e.Source())
+		}
+		if e != nil && r.tracePropagation {
+			var span *trace.Span
+			ctx, span = traceMessageSpan(ctx, m, *e)
+			defer span.End()
+		}
+
 		// Check if event is valid before invoking the receiver function
 		if e != nil {
-			if validationErr := e.Validate(); validationErr != nil {
+			if validationErr := e.ValidateWithLevel(r.validationLevel); validationErr != nil {
 				return respFn(ctx, nil, protocol.NewReceipt(false, "validation error in incoming event: %w", validationErr))
 			}
+			for _, fn := range r.eventValidatorFns {
+				if validationErr := fn(ctx, *e); validationErr != nil {
+					return respFn(ctx, nil, protocol.NewReceipt(false, "validation error in incoming event: %w", validationErr))
+				}
+			}
+		}
+
+		// Decode the data for typed receivers
+		var data reflect.Value
+		if r.fn.hasDataIn {
+			var dataErr error
+			if data, dataErr = r.fn.newData(e, r.registry); dataErr != nil {
+				return respFn(ctx, nil, protocol.NewReceipt(false, "failed to decode data of incoming event: %w", dataErr))
+			}
 		}
 
 		// Let's invoke the receiver fn
 		var resp *event.Event
 		resp, result = func() (resp *event.Event, result protocol.Result) {
+			ctx, cb := r.observabilityService.RecordCallingInvoker(ctx, e)
+			defer func() { cb(result) }()
 			defer func() {
 				if r := recover(); r != nil {
 					result = fmt.Errorf("call to Invoker.Invoke(...) has panicked: %v", r)
-					cecontext.LoggerFrom(ctx).Error(result)
+					cecontext.StructuredLoggerFrom(ctx).Errorw("receiver panicked", "error", result)
 				}
 			}()
-			resp, result = r.fn.invoke(ctx, e)
+			resp, result = r.fn.invokeWithData(ctx, e, data)
 			return
 		}()
 
@@ -81,10 +168,18 @@ func (r *receiveInvoker) Invoke(ctx context.Context, m binding.Message, respFn p
 			}
 			// Validate the event conforms to the CloudEvents Spec.
 			if vErr := resp.Validate(); vErr != nil {
-				cecontext.LoggerFrom(ctx).Errorf("cloudevent validation failed on response event: %v", vErr)
+				cecontext.StructuredLoggerFrom(ctx).Errorw("cloudevent validation failed on response event", "error", vErr)
 			}
 		}
 
+		// Route the response to the replyto destination
+		if resp != nil && r.replySender != nil {
+			if replyErr := r.reply(ctx, e, resp); replyErr != nil {
+				result = replyErr
+			}
+			resp = nil
+		}
+
 		// because binding.Message is an interface, casting a nil resp
 		// here would make future comparisons to nil false
 		if resp != nil {
@@ -97,7 +192,7 @@ func (r *receiveInvoker) Invoke(ctx context.Context, m binding.Message, respFn p
 		return result
 	}
 
-	return respFn(ctx, respMsg, result)
+	return respFn(ctx, respMsg, result, r.outboundTransformers...)
 }
 
 func (r *receiveInvoker) IsReceiver() bool {
@@ -107,3 +202,36 @@ func (r *receiveInvoker) IsReceiver() bool {
 func (r *receiveInvoker) IsResponder() bool {
 	return r.fn.hasEventOut
 }
+
+// reply sends resp, the response to e, to the replyto destination of e or to
+// the default one. e is nil when the received message isn't an event, then
+// resp is sent to the default destination without correlation id. A response
+// without destination is dropped.
+func (r *receiveInvoker) reply(ctx context.Context, e *event.Event, resp *event.Event) error {
+	var destination string
+	if e != nil {
+		destination, _ = extensions.GetReplyTo(*e)
+	}
+	if destination == "" {
+		destination = r.defaultReplyTo
+	}
+	if destination == "" {
+		cecontext.StructuredLoggerFrom(ctx).Warnw("dropping the response of an event without replyto destination", "responseId", resp.ID())
+		return nil
+	}
+	if _, ok := extensions.GetCorrelationID(*resp); !ok && e != nil {
+		extensions.SetCorrelationID(resp, e.ID())
+	}
+
+	ctx = cecontext.WithTopic(ctx, destination)
+	if target, err := url.Parse(destination); err == nil && target.IsAbs() {
+		ctx = cecontext.WithTarget(ctx, destination)
+	}
+	ctx, cb := r.observabilityService.RecordSendingEvent(ctx, *resp)
+	err := r.replySender.Send(ctx, (*binding.EventMessage)(resp), r.outboundTransformers...)
+	cb(err)
+	if protocol.IsACK(err) {
+		return nil
+	}
+	return protocol.NewReceipt(false, "failed to send the response to %q: %w", destination, err)
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/event"
 	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/observability"
@@ -82,13 +83,13 @@ func EventTraceAttributes(e event.EventReader) []trace.Attribute {
 
 // TraceSpan returns context and trace.Span based on event. Caller must call span.End()
 func TraceSpan(ctx context.Context, e event.Event) (context.Context, *trace.Span) {
-	var span *trace.Span
-	if ext, ok := extensions.GetDistributedTracingExtension(e); ok {
-		ctx, span = ext.StartChildSpan(ctx, observability.ClientSpanName, trace.WithSpanKind(trace.SpanKindServer))
-	}
-	if span == nil {
-		ctx, span = trace.StartSpan(ctx, observability.ClientSpanName, trace.WithSpanKind(trace.SpanKindServer))
-	}
+	return traceMessageSpan(ctx, nil, e)
+}
+
+// traceMessageSpan returns context and trace.Span based on the event and the
+// native trace context of the message it was received with, if any.
+func traceMessageSpan(ctx context.Context, m binding.Message, e event.Event) (context.Context, *trace.Span) {
+	ctx, span := extensions.StartConsumerSpan(ctx, observability.ClientSpanName, m, &e, trace.WithSpanKind(trace.SpanKindServer))
 	if span.IsRecordingEvents() {
 		span.AddAttributes(EventTraceAttributes(&e)...)
 	}
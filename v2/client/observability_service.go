@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+// ObservabilityService is the extension point used by the client to report
+// the events it sends and receives, e.g. to create tracing spans and record
+// metrics with an observability framework.
+// Set it with WithObservabilityService.
+type ObservabilityService interface {
+	// InboundContextDecorators returns the decorators applied to the context
+	// of each incoming message, e.g. to extract the trace context of the
+	// message headers.
+	InboundContextDecorators() []func(context.Context, binding.Message) context.Context
+	// RecordReceivedMalformedEvent is invoked when an incoming message cannot
+	// be converted to an event.
+	RecordReceivedMalformedEvent(ctx context.Context, err error)
+	// RecordCallingInvoker is invoked before calling the receiver with e. It
+	// returns the context passed to the receiver, and a function invoked with
+	// the result of the receiver. e is nil when the incoming message is not an
+	// event and the receiver doesn't take events.
+	RecordCallingInvoker(ctx context.Context, e *event.Event) (context.Context, func(errOrResult error))
+	// RecordSendingEvent is invoked before sending e. It returns the context
+	// used to send, and a function invoked with the result of the send.
+	RecordSendingEvent(ctx context.Context, e event.Event) (context.Context, func(errOrResult error))
+	// RecordRequestEvent is invoked before requesting with e. It returns the
+	// context used for the request, and a function invoked with the result and
+	// the response event, if any.
+	RecordRequestEvent(ctx context.Context, e event.Event) (context.Context, func(errOrResult error, event *event.Event))
+}
+
+// noopObservabilityService is the ObservabilityService used by default.
+type noopObservabilityService struct{}
+
+var _ ObservabilityService = noopObservabilityService{}
+
+func (noopObservabilityService) InboundContextDecorators() []func(context.Context, binding.Message) context.Context {
+	return nil
+}
+
+func (noopObservabilityService) RecordReceivedMalformedEvent(ctx context.Context, err error) {}
+
+func (noopObservabilityService) RecordCallingInvoker(ctx context.Context, e *event.Event) (context.Context, func(errOrResult error)) {
+	return ctx, func(errOrResult error) {}
+}
+
+func (noopObservabilityService) RecordSendingEvent(ctx context.Context, e event.Event) (context.Context, func(errOrResult error)) {
+	return ctx, func(errOrResult error) {}
+}
+
+func (noopObservabilityService) RecordRequestEvent(ctx context.Context, e event.Event) (context.Context, func(errOrResult error, event *event.Event)) {
+	return ctx, func(errOrResult error, event *event.Event) {}
+}
+
+// multiObservabilityService fans out the events to several services.
+type multiObservabilityService []ObservabilityService
+
+// MultiObservabilityService returns an ObservabilityService reporting the
+// events to all of services, e.g. to create OpenTelemetry spans and record
+// Prometheus metrics with the same client. The contexts are passed through the
+// services in order, and the result functions are invoked in the reverse order.
+func MultiObservabilityService(services ...ObservabilityService) ObservabilityService {
+	return multiObservabilityService(append([]ObservabilityService(nil), services...))
+}
+
+var _ ObservabilityService = multiObservabilityService(nil)
+
+func (m multiObservabilityService) InboundContextDecorators() []func(context.Context, binding.Message) context.Context {
+	var decorators []func(context.Context, binding.Message) context.Context
+	for _, s := range m {
+		decorators = append(decorators, s.InboundContextDecorators()...)
+	}
+	return decorators
+}
+
+func (m multiObservabilityService) RecordReceivedMalformedEvent(ctx context.Context, err error) {
+	for _, s := range m {
+		s.RecordReceivedMalformedEvent(ctx, err)
+	}
+}
+
+func (m multiObservabilityService) RecordCallingInvoker(ctx context.Context, e *event.Event) (context.Context, func(errOrResult error)) {
+	cbs := make([]func(errOrResult error), len(m))
+	for i, s := range m {
+		ctx, cbs[i] = s.RecordCallingInvoker(ctx, e)
+	}
+	return ctx, func(errOrResult error) {
+		for i := len(cbs) - 1; i >= 0; i-- {
+			cbs[i](errOrResult)
+		}
+	}
+}
+
+func (m multiObservabilityService) RecordSendingEvent(ctx context.Context, e event.Event) (context.Context, func(errOrResult error)) {
+	cbs := make([]func(errOrResult error), len(m))
+	for i, s := range m {
+		ctx, cbs[i] = s.RecordSendingEvent(ctx, e)
+	}
+	return ctx, func(errOrResult error) {
+		for i := len(cbs) - 1; i >= 0; i-- {
+			cbs[i](errOrResult)
+		}
+	}
+}
+
+func (m multiObservabilityService) RecordRequestEvent(ctx context.Context, e event.Event) (context.Context, func(errOrResult error, event *event.Event)) {
+	cbs := make([]func(errOrResult error, event *event.Event), len(m))
+	for i, s := range m {
+		ctx, cbs[i] = s.RecordRequestEvent(ctx, e)
+	}
+	return ctx, func(errOrResult error, event *event.Event) {
+		for i := len(cbs) - 1; i >= 0; i-- {
+			cbs[i](errOrResult, event)
+		}
+	}
+}
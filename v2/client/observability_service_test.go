@@ -0,0 +1,90 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/client"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol/gochan"
+)
+
+type recordKey string
+
+// recordingService records its calls in a shared log, and decorates the
+// contexts with its name.
+type recordingService struct {
+	name string
+	log  *[]string
+}
+
+func (s recordingService) record(call string, ctx context.Context) {
+	seen, _ := ctx.Value(recordKey("seen")).(string)
+	*s.log = append(*s.log, s.name+" "+call+seen)
+}
+
+func (s recordingService) decorate(ctx context.Context) context.Context {
+	seen, _ := ctx.Value(recordKey("seen")).(string)
+	return context.WithValue(ctx, recordKey("seen"), seen+" "+s.name)
+}
+
+func (s recordingService) InboundContextDecorators() []func(context.Context, binding.Message) context.Context {
+	return []func(context.Context, binding.Message) context.Context{
+		func(ctx context.Context, _ binding.Message) context.Context { return ctx },
+	}
+}
+
+func (s recordingService) RecordReceivedMalformedEvent(ctx context.Context, err error) {
+	s.record("malformed", ctx)
+}
+
+func (s recordingService) RecordCallingInvoker(ctx context.Context, e *event.Event) (context.Context, func(errOrResult error)) {
+	s.record("invoke", ctx)
+	return s.decorate(ctx), func(errOrResult error) { s.record("invoked", ctx) }
+}
+
+func (s recordingService) RecordSendingEvent(ctx context.Context, e event.Event) (context.Context, func(errOrResult error)) {
+	s.record("send", ctx)
+	return s.decorate(ctx), func(errOrResult error) { s.record("sent", ctx) }
+}
+
+func (s recordingService) RecordRequestEvent(ctx context.Context, e event.Event) (context.Context, func(errOrResult error, event *event.Event)) {
+	s.record("request", ctx)
+	return s.decorate(ctx), func(errOrResult error, event *event.Event) { s.record("requested", ctx) }
+}
+
+func TestMultiObservabilityService(t *testing.T) {
+	var log []string
+	service := client.MultiObservabilityService(recordingService{name: "a", log: &log}, recordingService{name: "b", log: &log})
+
+	if got := len(service.InboundContextDecorators()); got != 2 {
+		t.Errorf("unexpected number of decorators: %d", got)
+	}
+	service.RecordReceivedMalformedEvent(context.Background(), errors.New("malformed"))
+
+	c, err := client.New(gochan.New(), client.WithObservabilityService(service))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := event.New()
+	e.SetID("1")
+	e.SetType("unit.test")
+	e.SetSource("unit/test")
+	_ = c.Send(context.Background(), e)
+
+	want := []string{
+		"a malformed",
+		"b malformed",
+		"a send",
+		"b send a",
+		"b sent a",
+		"a sent",
+	}
+	if diff := cmp.Diff(want, log); diff != "" {
+		t.Errorf("unexpected calls (-want, +got) = %v", diff)
+	}
+}
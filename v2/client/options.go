@@ -2,7 +2,12 @@ package client
 
 import (
 	"fmt"
+
 	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/event/registry"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+	"github.com/cloudevents/sdk-go/v2/protocol"
 )
 
 // Option is the function signature required to be considered an client.Option.
@@ -21,6 +26,92 @@ func WithEventDefaulter(fn EventDefaulter) Option {
 	}
 }
 
+// WithEventValidator adds an event validator to the end of the validator
+// chain. Validators are invoked after the spec based validation, both on
+// outbound events and on incoming events before invoking the receiver.
+// A failure on an incoming event is reported as a NACK to the sender.
+func WithEventValidator(fn EventValidator) Option {
+	return func(i interface{}) error {
+		if c, ok := i.(*ceClient); ok {
+			if fn == nil {
+				return fmt.Errorf("client option was given an nil event validator")
+			}
+			c.eventValidatorFns = append(c.eventValidatorFns, fn)
+		}
+		return nil
+	}
+}
+
+// WithInboundEventValidator adds an event validator to the end of the
+// validator chain of the incoming events only. A failure is reported as a
+// NACK to the sender, without invoking the receiver.
+func WithInboundEventValidator(fn EventValidator) Option {
+	return func(i interface{}) error {
+		if c, ok := i.(*ceClient); ok {
+			if fn == nil {
+				return fmt.Errorf("client option was given an nil event validator")
+			}
+			c.inboundEventValidatorFns = append(c.inboundEventValidatorFns, fn)
+		}
+		return nil
+	}
+}
+
+// WithOutboundTransformers adds transformers applied when the outbound events,
+// including the events returned by the receiver, are written on the wire.
+// The transformers run on the event representation, so they can access the
+// event data regardless of the protocol.
+func WithOutboundTransformers(transformers ...binding.Transformer) Option {
+	return func(i interface{}) error {
+		if c, ok := i.(*ceClient); ok {
+			c.outboundTransformers = append(c.outboundTransformers, transformers...)
+		}
+		return nil
+	}
+}
+
+// WithInboundTransformers adds transformers applied when the incoming messages,
+// including the responses to requests, are converted to events, before the
+// validation and the invocation of the receiver. A transformer failure on an
+// incoming message is reported as a NACK to the sender.
+func WithInboundTransformers(transformers ...binding.Transformer) Option {
+	return func(i interface{}) error {
+		if c, ok := i.(*ceClient); ok {
+			c.inboundTransformers = append(c.inboundTransformers, transformers...)
+		}
+		return nil
+	}
+}
+
+// WithValidationLevel sets the strictness of the spec based validation
+// performed on outbound events and on incoming events before invoking the
+// receiver. Use event.ValidationLevelStrict to catch non-compliant events
+// before they reach other CloudEvents implementations.
+func WithValidationLevel(level event.ValidationLevel) Option {
+	return func(i interface{}) error {
+		if c, ok := i.(*ceClient); ok {
+			c.validationLevel = level
+		}
+		return nil
+	}
+}
+
+// WithSampling samples the events sent with Send, dropping the events not kept
+// by sampler before they are validated and sent. The dropped events are
+// reported as ACK to the caller, while the kept ones carry the sampledrate
+// extension. Requests are never sampled.
+func WithSampling(sampler *extensions.Sampler) Option {
+	return func(i interface{}) error {
+		if c, ok := i.(*ceClient); ok {
+			if sampler == nil {
+				return fmt.Errorf("client option was given a nil sampler")
+			}
+			c.sampler = sampler
+		}
+		return nil
+	}
+}
+
 func WithForceBinary() Option {
 	return func(i interface{}) error {
 		if c, ok := i.(*ceClient); ok {
@@ -62,12 +153,17 @@ func WithTimeNow() Option {
 }
 
 // WithTracePropagation enables trace propagation via the distributed tracing
-// extension.
+// extension, set on the sent events if missing, and the automatic tracing of
+// the incoming events: their spans are parented to the trace context of the
+// native protocol headers, or of the distributed tracing extension.
 func WithTracePropagation() Option {
 	return func(i interface{}) error {
 		if c, ok := i.(*obsClient); ok {
 			c.addTracing = true
 		}
+		if c, ok := i.(*ceClient); ok {
+			c.tracePropagation = true
+		}
 		return nil
 	}
 }
@@ -83,3 +179,55 @@ func WithPollGoroutines(pollGoroutines int) Option {
 		return nil
 	}
 }
+
+// WithEventRegistry configures the registry used to decode the data of
+// incoming events for typed receivers, like
+// func(context.Context, event.Event, *OrderCreated).
+func WithEventRegistry(reg *registry.Registry) Option {
+	return func(i interface{}) error {
+		if c, ok := i.(*ceClient); ok {
+			if reg == nil {
+				return fmt.Errorf("client option was given a nil event registry")
+			}
+			c.registry = reg
+		}
+		return nil
+	}
+}
+
+// WithObservabilityService sets the ObservabilityService recording the events
+// sent and received by the client, e.g. with OpenTelemetry. Combine several
+// services with MultiObservabilityService.
+func WithObservabilityService(service ObservabilityService) Option {
+	return func(i interface{}) error {
+		if c, ok := i.(*ceClient); ok {
+			if service == nil {
+				return fmt.Errorf("client option was given a nil observability service")
+			}
+			c.observabilityService = service
+		}
+		return nil
+	}
+}
+
+// WithReplySender routes the events returned by the receiver function to the
+// destination of the replyto extension of the received events, or to
+// defaultReplyTo, sending them with sender instead of responding on the same
+// exchange. The responses carry the correlationid extension set to the id of
+// the received event. The responses to received messages that aren't events
+// go to defaultReplyTo, without correlationid. It gives request/response semantics to one-way
+// protocols, e.g. Kafka or NATS: the destination is passed to sender with
+// cecontext.WithTopic, and also with cecontext.WithTarget when it is a URL.
+// The received message is not acknowledged when the response can't be sent.
+func WithReplySender(sender protocol.Sender, defaultReplyTo string) Option {
+	return func(i interface{}) error {
+		if c, ok := i.(*ceClient); ok {
+			if sender == nil {
+				return fmt.Errorf("client option was given a nil reply sender")
+			}
+			c.replySender = sender
+			c.defaultReplyTo = defaultReplyTo
+		}
+		return nil
+	}
+}
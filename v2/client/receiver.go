@@ -7,6 +7,7 @@ import (
 	"reflect"
 
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/event/registry"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 )
 
@@ -20,13 +21,15 @@ type receiverFn struct {
 
 	hasContextIn bool
 	hasEventIn   bool
+	hasDataIn    bool
+	dataType     reflect.Type
 
 	hasEventOut  bool
 	hasResultOut bool
 }
 
 const (
-	inParamUsage  = "expected a function taking either no parameters, one or more of (context.Context, event.Event) ordered"
+	inParamUsage  = "expected a function taking either no parameters, one or more of (context.Context, event.Event) ordered, or (context.Context, event.Event, data)"
 	outParamUsage = "expected a function returning one or mode of (*event.Event, protocol.Result) ordered"
 )
 
@@ -52,7 +55,9 @@ var (
 // * func(event.Event) (*event.Event, transport.Result)
 // * func(context.Context, event.Event, *event.Event
 // * func(context.Context, event.Event) (*event.Event, transport.Result)
-//
+// * func(context.Context, event.Event, data) with any of the outputs above
+// where data is either a pointer to a struct, or an interface filled using the
+// registry.Registry configured on the client.
 func receiver(fn interface{}) (*receiverFn, error) {
 	fnType := reflect.TypeOf(fn)
 	if fnType.Kind() != reflect.Func {
@@ -73,6 +78,30 @@ func receiver(fn interface{}) (*receiverFn, error) {
 }
 
 func (r *receiverFn) invoke(ctx context.Context, e *event.Event) (*event.Event, protocol.Result) {
+	return r.invokeWithData(ctx, e, reflect.Value{})
+}
+
+// newData allocates the data argument of a typed receiver and decodes the
+// event data into it. If reg has an entry for the event type, the registered
+// struct is used, otherwise the struct pointed by the receiver parameter.
+func (r *receiverFn) newData(e *event.Event, reg *registry.Registry) (reflect.Value, error) {
+	if reg != nil {
+		if entry, ok := reg.Lookup(e.Type(), e.DataSchema()); ok {
+			data := reflect.New(entry.GoType)
+			if !data.Type().AssignableTo(r.dataType) {
+				return reflect.Value{}, fmt.Errorf("event type %q is registered to %s, which cannot be used as %s", e.Type(), data.Type(), r.dataType)
+			}
+			return data, e.DataAs(data.Interface())
+		}
+	}
+	if r.dataType.Kind() != reflect.Ptr {
+		return reflect.Value{}, fmt.Errorf("%w: %q", registry.ErrNotRegistered, e.Type())
+	}
+	data := reflect.New(r.dataType.Elem())
+	return data, e.DataAs(data.Interface())
+}
+
+func (r *receiverFn) invokeWithData(ctx context.Context, e *event.Event, data reflect.Value) (*event.Event, protocol.Result) {
 	args := make([]reflect.Value, 0, r.numIn)
 
 	if r.numIn > 0 {
@@ -82,6 +111,9 @@ func (r *receiverFn) invoke(ctx context.Context, e *event.Event) (*event.Event,
 		if r.hasEventIn {
 			args = append(args, reflect.ValueOf(*e))
 		}
+		if r.hasDataIn {
+			args = append(args, data)
+		}
 	}
 	v := r.fnValue.Call(args)
 	var respOut protocol.Result
@@ -105,12 +137,32 @@ func (r *receiverFn) invoke(ctx context.Context, e *event.Event) (*event.Event,
 
 // Verifies that the inputs to a function have a valid signature
 // Valid input is to be [0, all] of
-// context.Context, event.Event in this order.
+// context.Context, event.Event in this order,
+// or context.Context, event.Event, data.
 func (r *receiverFn) validateInParamSignature(fnType reflect.Type) error {
 	r.hasContextIn = false
 	r.hasEventIn = false
+	r.hasDataIn = false
+	r.dataType = nil
 
 	switch fnType.NumIn() {
+	case 3:
+		// has to be (context.Context, event.Event, data)
+		dataType := fnType.In(2)
+		if !(dataType.Kind() == reflect.Ptr && dataType.Elem().Kind() == reflect.Struct) && dataType.Kind() != reflect.Interface {
+			return fmt.Errorf("%s; cannot use parameter 3 of type %s as data, expected a pointer to a struct or an interface", inParamUsage, dataType)
+		}
+		if !contextType.ConvertibleTo(fnType.In(0)) {
+			return fmt.Errorf("%s; cannot convert parameter 1 to %s from context.Context", inParamUsage, fnType.In(0))
+		}
+		if !eventType.ConvertibleTo(fnType.In(1)) {
+			return fmt.Errorf("%s; cannot convert parameter 2 to %s from event.Event", inParamUsage, fnType.In(1))
+		}
+		r.hasContextIn = true
+		r.hasEventIn = true
+		r.hasDataIn = true
+		r.dataType = dataType
+		return nil
 	case 2:
 		// has to be (context.Context, event.Event)
 		if !eventType.ConvertibleTo(fnType.In(1)) {
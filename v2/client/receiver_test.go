@@ -3,10 +3,16 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/transformer"
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/event/registry"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 
 	"github.com/google/go-cmp/cmp"
@@ -40,6 +46,9 @@ func TestReceiverFnValidTypes(t *testing.T) {
 		"Event in, Event+Result out":     func(event.Event) (*event.Event, protocol.Result) { return nil, nil },
 		"ctx+Event in, Event+Result out": func(context.Context, event.Event) (*event.Event, protocol.Result) { return nil, nil },
 
+		"ctx+Event+data in, no out":          func(context.Context, event.Event, *testData) {},
+		"ctx+Event+interface in, Result out": func(context.Context, event.Event, interface{}) protocol.Result { return nil },
+
 		"input contravariance; may accept supertype": func(event.EventReader) {},
 		"output covariance; may return subtype":      func() *myErr { return nil },
 	} {
@@ -56,6 +65,9 @@ func TestReceiverFnInvalidTypes(t *testing.T) {
 		"wrong type in":            func(string) {},
 		"wrong type out":           func() string { return "" },
 		"extra in":                 func(context.Context, event.Event, map[string]string) {},
+		"data as struct in":        func(context.Context, event.Event, testData) {},
+		"data without ctx in":      func(event.Event, event.Event, *testData) {},
+		"too many in":              func(context.Context, event.Event, *testData, *testData) {},
 		"extra out":                func(context.Context) (int, error) { return 0, nil },
 		"dup error out":            func(context.Context) (protocol.Result, error) { return nil, nil },
 		"context dup Event out":    func(context.Context) (*event.Event, *event.Event) { return nil, nil },
@@ -236,6 +248,167 @@ func TestReceiverFnInvoke_5(t *testing.T) {
 	}
 }
 
+func TestReceiverFnInvoke_Data(t *testing.T) {
+	e := event.New()
+	e.SetType("unit.test")
+	require.NoError(t, e.SetData(event.ApplicationJSON, testData{Message: "hello"}))
+
+	fn, err := receiver(func(ctx context.Context, e event.Event, data *testData) protocol.Result {
+		if data.Message != "hello" {
+			return fmt.Errorf("unexpected data %v", data)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	data, err := fn.newData(&e, nil)
+	require.NoError(t, err)
+
+	_, result := fn.invokeWithData(context.TODO(), &e, data)
+	require.NoError(t, result)
+}
+
+func TestReceiverFnInvoke_RegistryData(t *testing.T) {
+	reg := registry.New()
+	reg.MustRegister("unit.test", testData{})
+
+	e, err := reg.NewEvent(&testData{Message: "hello"})
+	require.NoError(t, err)
+
+	var got interface{}
+	fn, err := receiver(func(ctx context.Context, e event.Event, data interface{}) {
+		got = data
+	})
+	require.NoError(t, err)
+
+	data, err := fn.newData(&e, reg)
+	require.NoError(t, err)
+	fn.invokeWithData(context.TODO(), &e, data)
+	require.Equal(t, &testData{Message: "hello"}, got)
+
+	e.SetType("unit.test.unknown")
+	_, err = fn.newData(&e, reg)
+	require.True(t, errors.Is(err, registry.ErrNotRegistered))
+}
+
+func TestReceiverFnInvoke_RegistryMismatch(t *testing.T) {
+	type otherData struct{}
+	reg := registry.New()
+	reg.MustRegister("unit.test", otherData{})
+
+	e := event.New()
+	e.SetType("unit.test")
+
+	fn, err := receiver(func(ctx context.Context, e event.Event, data *testData) {})
+	require.NoError(t, err)
+
+	_, err = fn.newData(&e, reg)
+	require.Error(t, err)
+}
+
+func TestReceiveInvoker_DataDecodeFailure(t *testing.T) {
+	invoker, err := newReceiveInvoker(func(ctx context.Context, e event.Event, data *testData) {
+		t.Errorf("receiver should not be invoked")
+	}, invokerConfig{})
+	require.NoError(t, err)
+
+	e := event.New()
+	e.SetID("1")
+	e.SetType("unit.test")
+	e.SetSource("/unit/test")
+	require.NoError(t, e.SetData(event.ApplicationJSON, []byte("not json")))
+
+	var result protocol.Result
+	_ = invoker.Invoke(context.TODO(), binding.ToMessage(&e), func(ctx context.Context, m binding.Message, r protocol.Result, _ ...binding.Transformer) error {
+		result = r
+		return nil
+	})
+	require.True(t, protocol.IsNACK(result))
+
+	_, err = newReceiveInvoker(func(ctx context.Context, e event.Event, data interface{}) {}, invokerConfig{})
+	require.Error(t, err, "interface data requires a registry")
+}
+
+func TestReceiveInvoker_EventValidator(t *testing.T) {
+	invoker, err := newReceiveInvoker(func(ctx context.Context, e event.Event) {
+		t.Errorf("receiver should not be invoked")
+	}, invokerConfig{eventValidatorFns: []EventValidator{
+		func(ctx context.Context, e event.Event) error {
+			return event.ValidationError{"data": errors.New("invalid")}
+		},
+	}})
+	require.NoError(t, err)
+
+	e := event.New()
+	e.SetID("1")
+	e.SetType("unit.test")
+	e.SetSource("/unit/test")
+
+	var result protocol.Result
+	_ = invoker.Invoke(context.TODO(), binding.ToMessage(&e), func(ctx context.Context, m binding.Message, r protocol.Result, _ ...binding.Transformer) error {
+		result = r
+		return nil
+	})
+	require.True(t, protocol.IsNACK(result))
+	var validationErr event.ValidationError
+	require.True(t, errors.As(result, &validationErr))
+}
+
+func TestReceiveInvoker_StrictValidationLevel(t *testing.T) {
+	invoker, err := newReceiveInvoker(func(ctx context.Context, e event.Event) {
+		t.Errorf("receiver should not be invoked")
+	}, invokerConfig{validationLevel: event.ValidationLevelStrict})
+	require.NoError(t, err)
+
+	e := event.New()
+	e.SetID("1")
+	e.SetType("unit.test")
+	e.SetSource("/unit/test")
+	e.SetDataSchema("/relative/schema")
+
+	var result protocol.Result
+	_ = invoker.Invoke(context.TODO(), binding.ToMessage(&e), func(ctx context.Context, m binding.Message, r protocol.Result, _ ...binding.Transformer) error {
+		result = r
+		return nil
+	})
+	require.True(t, protocol.IsNACK(result))
+	var validationErr event.ValidationError
+	require.True(t, errors.As(result, &validationErr))
+	require.Contains(t, validationErr, "dataschema")
+}
+
+func TestReceiveInvoker_Transformers(t *testing.T) {
+	invoker, err := newReceiveInvoker(func(ctx context.Context, e event.Event) *event.Event {
+		require.Equal(t, "inbound", e.Extensions()["transformed"])
+		resp := e.Clone()
+		return &resp
+	}, invokerConfig{
+		inboundTransformers: binding.Transformers{transformer.AddExtension("transformed", "inbound")},
+		outboundTransformers: binding.Transformers{transformer.SetExtension("transformed", func(interface{}) (interface{}, error) {
+			return "outbound", nil
+		})},
+	})
+	require.NoError(t, err)
+
+	e := event.New()
+	e.SetID("1")
+	e.SetType("unit.test")
+	e.SetSource("/unit/test")
+
+	var got *event.Event
+	_ = invoker.Invoke(context.TODO(), binding.ToMessage(&e), func(ctx context.Context, m binding.Message, r protocol.Result, transformers ...binding.Transformer) error {
+		require.True(t, protocol.IsACK(r))
+		got, err = binding.ToEvent(ctx, m, transformers...)
+		return err
+	})
+	require.NotNil(t, got)
+	require.Equal(t, "outbound", got.Extensions()["transformed"])
+}
+
+type testData struct {
+	Message string `json:"message"`
+}
+
 type myErr struct {
 }
 
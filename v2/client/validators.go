@@ -0,0 +1,13 @@
+package client
+
+import (
+	"context"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+// EventValidator is the function signature for extensions that are able
+// to perform additional event validation, on top of the spec based
+// validation performed by event.Event.Validate.
+// Failures should be reported as event.ValidationError.
+type EventValidator func(ctx context.Context, event event.Event) error
@@ -2,42 +2,107 @@ package context
 
 import (
 	"context"
+	"sync"
 
 	"go.uber.org/zap"
 )
 
+// Logger is the logging interface used by the SDK. The keysAndValues are
+// alternating keys and values, as in zap.SugaredLogger.Infow.
+// Adapters are provided for zap (NewZapLogger), logr (NewLogrLogger) and the
+// standard log package (NewStdLogger).
+type Logger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+	// With returns a Logger adding keysAndValues to each entry.
+	With(keysAndValues ...interface{}) Logger
+}
+
 // Opaque key type used to store logger
 type loggerKeyType struct{}
 
 var loggerKey = loggerKeyType{}
 
-// fallbackLogger is the logger is used when there is no logger attached to the context.
-var fallbackLogger *zap.SugaredLogger
+var (
+	fallbackMux sync.RWMutex
+	// fallbackLogger is the logger is used when there is no logger attached to
+	// the context. It is created when first used, unless set with
+	// SetFallbackLogger.
+	fallbackLogger Logger
+)
+
+// SetFallbackLogger sets the logger used when there is no logger attached to
+// the context, e.g. NopLogger to silence the SDK. Default is a production zap
+// logger named "fallback".
+func SetFallbackLogger(logger Logger) {
+	fallbackMux.Lock()
+	defer fallbackMux.Unlock()
+	fallbackLogger = logger
+}
 
-func init() {
-	if logger, err := zap.NewProduction(); err != nil {
-		// We failed to create a fallback logger.
-		fallbackLogger = zap.NewNop().Sugar()
-	} else {
-		fallbackLogger = logger.Named("fallback").Sugar()
+func getFallbackLogger() Logger {
+	fallbackMux.RLock()
+	logger := fallbackLogger
+	fallbackMux.RUnlock()
+	if logger != nil {
+		return logger
 	}
+
+	fallbackMux.Lock()
+	defer fallbackMux.Unlock()
+	if fallbackLogger == nil {
+		if logger, err := zap.NewProduction(); err != nil {
+			// We failed to create a fallback logger.
+			fallbackLogger = NopLogger
+		} else {
+			fallbackLogger = NewZapLogger(logger.Named("fallback").Sugar())
+		}
+	}
+	return fallbackLogger
 }
 
-// WithLogger returns a new context with the logger injected into the given context.
+// WithLogger returns a new context with the zap logger injected into the given
+// context. It is the same as WithStructuredLogger(ctx, NewZapLogger(logger)).
 func WithLogger(ctx context.Context, logger *zap.SugaredLogger) context.Context {
 	if logger == nil {
-		return context.WithValue(ctx, loggerKey, fallbackLogger)
+		return context.WithValue(ctx, loggerKey, getFallbackLogger())
 	}
-	return context.WithValue(ctx, loggerKey, logger)
+	return context.WithValue(ctx, loggerKey, NewZapLogger(logger))
 }
 
-// LoggerFrom returns the logger stored in context.
+// LoggerFrom returns the zap logger stored in context. When the logger of the
+// context, or the fallback logger, is not a zap logger, the returned zap
+// logger writes its entries to it: prefer StructuredLoggerFrom in new code.
 func LoggerFrom(ctx context.Context) *zap.SugaredLogger {
-	l := ctx.Value(loggerKey)
-	if l != nil {
-		if logger, ok := l.(*zap.SugaredLogger); ok {
-			return logger
-		}
+	logger := StructuredLoggerFrom(ctx)
+	if l, ok := logger.(*zapLogger); ok {
+		return l.SugaredLogger
 	}
-	return fallbackLogger
+	return zap.New(&loggerCore{logger: logger}).Sugar()
+}
+
+// WithStructuredLogger returns a new context with the logger injected into the
+// given context.
+func WithStructuredLogger(ctx context.Context, logger Logger) context.Context {
+	if logger == nil {
+		return context.WithValue(ctx, loggerKey, getFallbackLogger())
+	}
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// StructuredLoggerFrom returns the logger stored in context, or the fallback
+// logger.
+func StructuredLoggerFrom(ctx context.Context) Logger {
+	if logger, ok := ctx.Value(loggerKey).(Logger); ok {
+		return logger
+	}
+	return getFallbackLogger()
+}
+
+// WithLoggerFields returns a new context with a logger adding keysAndValues to
+// each entry of the logger stored in context.
+func WithLoggerFields(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	return context.WithValue(ctx, loggerKey, StructuredLoggerFrom(ctx).With(keysAndValues...))
 }
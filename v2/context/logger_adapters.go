@@ -0,0 +1,227 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NopLogger is a Logger discarding all the entries.
+var NopLogger Logger = nopLogger{}
+
+type nopLogger struct{}
+
+func (nopLogger) Debugw(string, ...interface{}) {}
+func (nopLogger) Infow(string, ...interface{})  {}
+func (nopLogger) Warnw(string, ...interface{})  {}
+func (nopLogger) Errorw(string, ...interface{}) {}
+func (l nopLogger) With(...interface{}) Logger  { return l }
+
+type zapLogger struct {
+	*zap.SugaredLogger
+}
+
+// NewZapLogger returns a Logger writing to logger.
+func NewZapLogger(logger *zap.SugaredLogger) Logger {
+	return &zapLogger{SugaredLogger: logger}
+}
+
+func (l *zapLogger) With(keysAndValues ...interface{}) Logger {
+	return &zapLogger{SugaredLogger: l.SugaredLogger.With(keysAndValues...)}
+}
+
+// loggerCore is a zapcore.Core writing the entries to a Logger, to return a
+// zap logger from LoggerFrom when the logger of the context is not a zap one.
+type loggerCore struct {
+	logger Logger
+}
+
+func (c *loggerCore) Enabled(zapcore.Level) bool { return true }
+
+func (c *loggerCore) With(fields []zapcore.Field) zapcore.Core {
+	return &loggerCore{logger: c.logger.With(keysAndValues(fields)...)}
+}
+
+func (c *loggerCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checked.AddCore(entry, c)
+}
+
+func (c *loggerCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	kvs := keysAndValues(fields)
+	if entry.LoggerName != "" {
+		kvs = append([]interface{}{"logger", entry.LoggerName}, kvs...)
+	}
+	switch entry.Level {
+	case zapcore.DebugLevel:
+		c.logger.Debugw(entry.Message, kvs...)
+	case zapcore.InfoLevel:
+		c.logger.Infow(entry.Message, kvs...)
+	case zapcore.WarnLevel:
+		c.logger.Warnw(entry.Message, kvs...)
+	default:
+		c.logger.Errorw(entry.Message, kvs...)
+	}
+	return nil
+}
+
+func (c *loggerCore) Sync() error { return nil }
+
+// keysAndValues converts the zap fields to alternating keys and values, sorted
+// by key.
+func keysAndValues(fields []zapcore.Field) []interface{} {
+	if len(fields) == 0 {
+		return nil
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	keys := make([]string, 0, len(enc.Fields))
+	for k := range enc.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs := make([]interface{}, 0, 2*len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, k, enc.Fields[k])
+	}
+	return kvs
+}
+
+type logrLogger struct {
+	logr.Logger
+}
+
+// NewLogrLogger returns a Logger writing to logger. The debug entries are
+// written at verbosity 1, the other entries at verbosity 0, with a "level"
+// key for the warnings. The value of the "error" key, if any, is the error of
+// the error entries.
+func NewLogrLogger(logger logr.Logger) Logger {
+	return &logrLogger{Logger: logger}
+}
+
+func (l *logrLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	l.Logger.V(1).Info(msg, keysAndValues...)
+}
+
+func (l *logrLogger) Infow(msg string, keysAndValues ...interface{}) {
+	l.Logger.Info(msg, keysAndValues...)
+}
+
+func (l *logrLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	l.Logger.Info(msg, append([]interface{}{"level", "warn"}, keysAndValues...)...)
+}
+
+func (l *logrLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	var err error
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		if keysAndValues[i] == "error" {
+			if e, ok := keysAndValues[i+1].(error); ok {
+				err = e
+				keysAndValues = append(keysAndValues[:i:i], keysAndValues[i+2:]...)
+				break
+			}
+		}
+	}
+	if err == nil {
+		err = errors.New(msg)
+	}
+	l.Logger.Error(err, msg, keysAndValues...)
+}
+
+func (l *logrLogger) With(keysAndValues ...interface{}) Logger {
+	return &logrLogger{Logger: l.Logger.WithValues(keysAndValues...)}
+}
+
+// Level is the severity of a log entry.
+type Level int
+
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "DEBUG"
+	case InfoLevel:
+		return "INFO"
+	case WarnLevel:
+		return "WARN"
+	case ErrorLevel:
+		return "ERROR"
+	}
+	return ""
+}
+
+type stdLogger struct {
+	logger *log.Logger
+	level  Level
+	fields []interface{}
+}
+
+// NewStdLogger returns a Logger writing the entries of at least level to
+// logger, formatted as "LEVEL msg key=value ...". A nil logger writes to the
+// standard logger of the log package.
+func NewStdLogger(logger *log.Logger, level Level) Logger {
+	if logger == nil {
+		logger = log.New(log.Writer(), log.Prefix(), log.Flags())
+	}
+	return &stdLogger{logger: logger, level: level}
+}
+
+func (l *stdLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	l.output(DebugLevel, msg, keysAndValues)
+}
+
+func (l *stdLogger) Infow(msg string, keysAndValues ...interface{}) {
+	l.output(InfoLevel, msg, keysAndValues)
+}
+
+func (l *stdLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	l.output(WarnLevel, msg, keysAndValues)
+}
+
+func (l *stdLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	l.output(ErrorLevel, msg, keysAndValues)
+}
+
+func (l *stdLogger) With(keysAndValues ...interface{}) Logger {
+	fields := make([]interface{}, 0, len(l.fields)+len(keysAndValues))
+	fields = append(append(fields, l.fields...), keysAndValues...)
+	return &stdLogger{logger: l.logger, level: l.level, fields: fields}
+}
+
+func (l *stdLogger) output(level Level, msg string, keysAndValues []interface{}) {
+	if level < l.level {
+		return
+	}
+	var b strings.Builder
+	b.WriteString(level.String())
+	b.WriteByte(' ')
+	b.WriteString(msg)
+	writeFields(&b, l.fields)
+	writeFields(&b, keysAndValues)
+	_ = l.logger.Output(3, b.String())
+}
+
+func writeFields(b *strings.Builder, keysAndValues []interface{}) {
+	for i := 0; i < len(keysAndValues); i += 2 {
+		b.WriteByte(' ')
+		if i+1 < len(keysAndValues) {
+			fmt.Fprintf(b, "%v=%v", keysAndValues[i], keysAndValues[i+1])
+		} else {
+			// A key without value
+			fmt.Fprintf(b, "%v", keysAndValues[i])
+		}
+	}
+}
@@ -1,11 +1,17 @@
 package context
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -47,3 +53,79 @@ func TestLoggerContext(t *testing.T) {
 		})
 	}
 }
+
+func TestStructuredLoggerContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStdLogger(log.New(&buf, "", 0), InfoLevel)
+
+	ctx := WithStructuredLogger(context.TODO(), logger)
+	require.Equal(t, logger, StructuredLoggerFrom(ctx))
+	// Not a zap logger: the zap logger writes to it
+	LoggerFrom(ctx).Named("zap").With("b", 2, "a", "x").Warnw("from zap", "count", 1)
+	LoggerFrom(ctx).Debugw("filtered")
+	require.Equal(t, "WARN from zap a=x b=2 logger=zap count=1\n", buf.String())
+	buf.Reset()
+
+	ctx = WithLoggerFields(ctx, "id", "1", "type", "example")
+	StructuredLoggerFrom(ctx).Debugw("filtered")
+	StructuredLoggerFrom(ctx).Warnw("hello", "error", errors.New("failed"))
+	require.Equal(t, "WARN hello id=1 type=example error=failed\n", buf.String())
+
+	zapLogger := zap.NewNop().Sugar()
+	ctx = WithLogger(context.TODO(), zapLogger)
+	require.Same(t, zapLogger, LoggerFrom(ctx))
+	require.Same(t, zapLogger, LoggerFrom(WithStructuredLogger(ctx, NewZapLogger(zapLogger))))
+}
+
+func TestFallbackLogger(t *testing.T) {
+	defer SetFallbackLogger(nil)
+
+	SetFallbackLogger(NopLogger)
+	require.Equal(t, NopLogger, StructuredLoggerFrom(context.TODO()))
+	require.Equal(t, NopLogger, StructuredLoggerFrom(WithStructuredLogger(context.TODO(), nil)))
+}
+
+// recordingLogr is a logr.Logger recording the entries of verbosity 0.
+type recordingLogr struct {
+	verbosity int
+	values    []interface{}
+	entries   *[]string
+}
+
+func (l recordingLogr) Enabled() bool { return l.verbosity == 0 }
+
+func (l recordingLogr) Info(msg string, keysAndValues ...interface{}) {
+	if l.Enabled() {
+		*l.entries = append(*l.entries, fmt.Sprintf("%s %v", msg, append(l.values, keysAndValues...)))
+	}
+}
+
+func (l recordingLogr) Error(err error, msg string, keysAndValues ...interface{}) {
+	*l.entries = append(*l.entries, fmt.Sprintf("%s: %v %v", msg, err, append(l.values, keysAndValues...)))
+}
+
+func (l recordingLogr) V(level int) logr.Logger {
+	l.verbosity += level
+	return l
+}
+
+func (l recordingLogr) WithValues(keysAndValues ...interface{}) logr.Logger {
+	l.values = append(append([]interface{}(nil), l.values...), keysAndValues...)
+	return l
+}
+
+func (l recordingLogr) WithName(string) logr.Logger { return l }
+
+func TestLogrLogger(t *testing.T) {
+	var entries []string
+	logger := NewLogrLogger(recordingLogr{entries: &entries})
+
+	logger = logger.With("id", "1")
+	logger.Debugw("filtered")
+	logger.Warnw("warning", "count", 2)
+	logger.Errorw("failure", "error", errors.New("failed"), "count", 3)
+	require.Equal(t, []string{
+		"warning [id 1 level warn count 2]",
+		"failure: failed [id 1 count 3]",
+	}, entries)
+}
@@ -153,6 +153,126 @@ func TestSchemaURL(t *testing.T) {
 	}
 }
 
+func TestValidateWithLevel(t *testing.T) {
+	now := types.Timestamp{Time: time.Now()}
+
+	withExtensions := func(ec *event.EventContextV1, ext map[string]interface{}) *event.EventContextV1 {
+		ec.Extensions = ext
+		return ec
+	}
+	withSource := func(ec *event.EventContextV1, source string) *event.EventContextV1 {
+		_ = ec.SetSource(source)
+		return ec
+	}
+	withDataSchema := func(ec *event.EventContextV1, dataSchema string) *event.EventContextV1 {
+		u, _ := url.Parse(dataSchema)
+		ec.DataSchema = &types.URI{URL: *u}
+		return ec
+	}
+
+	testCases := map[string]struct {
+		event     event.Event
+		level     event.ValidationLevel
+		want      []string
+		wantValid bool
+	}{
+		"full v1.0, strict": {
+			event:     event.Event{Context: FullEventContextV1(now)},
+			level:     event.ValidationLevelStrict,
+			wantValid: true,
+		},
+		"full v0.3, strict": {
+			event:     event.Event{Context: FullEventContextV03(now)},
+			level:     event.ValidationLevelStrict,
+			wantValid: true,
+		},
+		"relative dataschema, default": {
+			event:     event.Event{Context: withDataSchema(FullEventContextV1(now), "/schema")},
+			level:     event.ValidationLevelDefault,
+			wantValid: true,
+		},
+		"relative dataschema, strict": {
+			event: event.Event{Context: withDataSchema(FullEventContextV1(now), "/schema")},
+			level: event.ValidationLevelStrict,
+			want:  []string{"dataschema: if present, MUST be an absolute URI"},
+		},
+		"urn source, strict": {
+			event:     event.Event{Context: withSource(MinEventContextV1(), "urn:uuid:6e8bc430-9c3a-11d9-9669-0800200c9a66")},
+			level:     event.ValidationLevelStrict,
+			wantValid: true,
+		},
+		"ipv6 source, strict": {
+			event:     event.Event{Context: withSource(MinEventContextV1(), "http://[::1]:8080/source?a=b#c")},
+			level:     event.ValidationLevelStrict,
+			wantValid: true,
+		},
+		"relative source, strict": {
+			event:     event.Event{Context: withSource(MinEventContextV1(), "/cloudevents/spec/pull/123")},
+			level:     event.ValidationLevelStrict,
+			wantValid: true,
+		},
+		"source with space, default": {
+			event:     event.Event{Context: withSource(MinEventContextV1(), "mailto:a b")},
+			level:     event.ValidationLevelDefault,
+			wantValid: true,
+		},
+		"source with space, strict": {
+			event: event.Event{Context: withSource(MinEventContextV1(), "mailto:a b")},
+			level: event.ValidationLevelStrict,
+			want:  []string{"source: MUST be a non-empty URI-reference"},
+		},
+		"source with invalid query, strict": {
+			event: event.Event{Context: withSource(MinEventContextV1(), "http://example.com/?a b")},
+			level: event.ValidationLevelStrict,
+			want:  []string{"source: MUST be a non-empty URI-reference"},
+		},
+		"source with invalid character, strict": {
+			event: event.Event{Context: withSource(MinEventContextV1(), "urn:a|b")},
+			level: event.ValidationLevelStrict,
+			want:  []string{"source: MUST be a non-empty URI-reference"},
+		},
+		"upper case extension name, default": {
+			event:     event.Event{Context: withExtensions(MinEventContextV1(), map[string]interface{}{"MyExt": "a"})},
+			level:     event.ValidationLevelDefault,
+			wantValid: true,
+		},
+		"upper case extension name, strict": {
+			event: event.Event{Context: withExtensions(MinEventContextV1(), map[string]interface{}{"MyExt": "a"})},
+			level: event.ValidationLevelStrict,
+			want:  []string{"MyExt: extension name MUST consist of lower-case letters"},
+		},
+		"long extension name, strict": {
+			event: event.Event{Context: withExtensions(MinEventContextV1(), map[string]interface{}{"averyveryverylongextension": "a"})},
+			level: event.ValidationLevelStrict,
+			want:  []string{"averyveryverylongextension: extension name SHOULD NOT exceed 20 characters"},
+		},
+		"reserved extension name, strict": {
+			event: event.Event{Context: withExtensions(MinEventContextV1(), map[string]interface{}{"data": "a"})},
+			level: event.ValidationLevelStrict,
+			want:  []string{"data: extension name MUST NOT be a reserved attribute name"},
+		},
+		"invalid extension value, strict": {
+			event: event.Event{Context: withExtensions(MinEventContextV1(), map[string]interface{}{"ext": []string{"a"}})},
+			level: event.ValidationLevelStrict,
+			want:  []string{"ext: extension value MUST be a CloudEvents type"},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got := tc.event.ValidateWithLevel(tc.level)
+			if tc.wantValid {
+				require.NoError(t, got)
+				return
+			}
+			require.Error(t, got)
+			require.IsType(t, event.ValidationError{}, got)
+			for _, want := range tc.want {
+				require.Contains(t, got.Error(), want)
+			}
+		})
+	}
+}
+
 type DataExample struct {
 	AnInt   int                       `json:"a,omitempty"`
 	AString string                    `json:"b,omitempty"`
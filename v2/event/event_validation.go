@@ -2,25 +2,128 @@ package event
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
 	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/types"
 )
 
 type ValidationError map[string]error
 
 func (e ValidationError) Error() string {
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
 	b := strings.Builder{}
-	for k, v := range e {
+	for _, k := range keys {
 		b.WriteString(k)
 		b.WriteString(": ")
-		b.WriteString(v.Error())
+		b.WriteString(e[k].Error())
 		b.WriteRune('\n')
 	}
 	return b.String()
 }
 
+// ValidationLevel configures how strictly an event is validated against the
+// CloudEvents spec.
+type ValidationLevel int
+
+const (
+	// ValidationLevelDefault checks the REQUIRED attributes and the format of
+	// the OPTIONAL ones. This is the level used by Event.Validate.
+	ValidationLevelDefault ValidationLevel = iota
+	// ValidationLevelStrict additionally checks that source is a valid
+	// URI-reference, that dataschema is an absolute URI, that extension names
+	// are lower-case alphanumeric, at most 20 characters long and not reserved,
+	// and that extension values are valid CloudEvents types.
+	ValidationLevelStrict
+)
+
+// String returns the name of the validation level.
+func (l ValidationLevel) String() string {
+	switch l {
+	case ValidationLevelDefault:
+		return "default"
+	case ValidationLevelStrict:
+		return "strict"
+	default:
+		return fmt.Sprintf("ValidationLevel(%d)", int(l))
+	}
+}
+
+const maxExtensionNameLength = 20
+
+var isLowerAlphaNumeric = regexp.MustCompile(`^[a-z0-9]+$`).MatchString
+
+// uriReference matches the URI-reference grammar of RFC 3986, section 4.1.
+// The IPv6 literals are captured to be checked with net.ParseIP.
+var uriReference = func() *regexp.Regexp {
+	const (
+		pct          = `%[0-9A-Fa-f]{2}`
+		pchar        = `(?:[A-Za-z0-9\-._~!$&'()*+,;=:@]|` + pct + `)`
+		segment      = pchar + `*`
+		segmentNz    = pchar + `+`
+		segmentNzNc  = `(?:[A-Za-z0-9\-._~!$&'()*+,;=@]|` + pct + `)+`
+		scheme       = `[A-Za-z][A-Za-z0-9+\-.]*`
+		userinfo     = `(?:[A-Za-z0-9\-._~!$&'()*+,;=:]|` + pct + `)*`
+		ipLiteral    = `\[(?:([0-9A-Fa-f:.]+)|v[0-9A-Fa-f]+\.[A-Za-z0-9\-._~!$&'()*+,;=:]+)\]`
+		regName      = `(?:[A-Za-z0-9\-._~!$&'()*+,;=]|` + pct + `)*`
+		authority    = `(?:` + userinfo + `@)?(?:` + ipLiteral + `|` + regName + `)(?::[0-9]*)?`
+		pathAbempty  = `(?:/` + segment + `)*`
+		pathAbsolute = `/(?:` + segmentNz + `(?:/` + segment + `)*)?`
+		pathRootless = segmentNz + `(?:/` + segment + `)*`
+		pathNoscheme = segmentNzNc + `(?:/` + segment + `)*`
+		hierPart     = `(?://` + authority + pathAbempty + `|` + pathAbsolute + `|` + pathRootless + `|)`
+		relativePart = `(?://` + authority + pathAbempty + `|` + pathAbsolute + `|` + pathNoscheme + `|)`
+		query        = `(?:` + pchar + `|[/?])*`
+	)
+	return regexp.MustCompile(`^(?:` + scheme + `:` + hierPart + `|` + relativePart + `)(?:\?` + query + `)?(?:#` + query + `)?$`)
+}()
+
+// isURIReference reports whether s is a URI-reference as defined by RFC 3986.
+func isURIReference(s string) bool {
+	m := uriReference.FindStringSubmatch(s)
+	if m == nil {
+		return false
+	}
+	for _, ip := range m[1:] {
+		if ip != "" && (!strings.Contains(ip, ":") || net.ParseIP(ip) == nil) {
+			return false
+		}
+	}
+	return true
+}
+
+// reservedAttributeNames are the names that extensions must not use.
+var reservedAttributeNames = map[string]bool{
+	"specversion":     true,
+	"id":              true,
+	"source":          true,
+	"type":            true,
+	"datacontenttype": true,
+	"dataschema":      true,
+	"schemaurl":       true,
+	"subject":         true,
+	"time":            true,
+	"data":            true,
+}
+
 // Validate performs a spec based validation on this event.
 // Validation is dependent on the spec version specified in the event context.
 func (e Event) Validate() error {
+	return e.ValidateWithLevel(ValidationLevelDefault)
+}
+
+// ValidateWithLevel performs a spec based validation on this event, with the
+// provided strictness level.
+// Validation is dependent on the spec version specified in the event context.
+func (e Event) ValidateWithLevel(level ValidationLevel) error {
 	if e.Context == nil {
 		return ValidationError{"specversion": fmt.Errorf("missing Event.Context")}
 	}
@@ -38,8 +141,59 @@ func (e Event) Validate() error {
 		}
 	}
 
+	if level >= ValidationLevelStrict {
+		for k, v := range validateStrict(e.Context) {
+			if _, ok := errs[k]; !ok {
+				errs[k] = v
+			}
+		}
+	}
+
 	if len(errs) > 0 {
 		return ValidationError(errs)
 	}
 	return nil
 }
+
+// validateStrict checks the spec rules not covered by EventContext.Validate.
+func validateStrict(ec EventContext) map[string]error {
+	errs := map[string]error{}
+
+	// source
+	//  MUST be a non-empty URI-reference
+	if source := strings.TrimSpace(ec.GetSource()); source != "" {
+		if !isURIReference(source) {
+			errs["source"] = fmt.Errorf("MUST be a non-empty URI-reference")
+		}
+	}
+
+	// dataschema
+	//  If present, MUST adhere to the format specified in RFC 3986 (absolute URI)
+	if ec.GetSpecVersion() == CloudEventsVersionV1 {
+		if dataSchema := ec.GetDataSchema(); dataSchema != "" {
+			if u, err := url.Parse(dataSchema); err != nil || !u.IsAbs() {
+				errs["dataschema"] = fmt.Errorf("if present, MUST be an absolute URI")
+			}
+		}
+	}
+
+	// extensions
+	//  Names MUST consist of lower-case letters or digits and SHOULD NOT exceed 20 characters.
+	//  Values MUST be of a CloudEvents type.
+	for name, value := range ec.GetExtensions() {
+		switch {
+		case !isLowerAlphaNumeric(name):
+			errs[name] = fmt.Errorf("extension name MUST consist of lower-case letters ('a' to 'z') or digits ('0' to '9')")
+		case len(name) > maxExtensionNameLength:
+			errs[name] = fmt.Errorf("extension name SHOULD NOT exceed %d characters", maxExtensionNameLength)
+		case reservedAttributeNames[name]:
+			errs[name] = fmt.Errorf("extension name MUST NOT be a reserved attribute name")
+		default:
+			if _, err := types.Validate(value); err != nil {
+				errs[name] = fmt.Errorf("extension value MUST be a CloudEvents type: %w", err)
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,9 @@
+/*
+Package jsonschema implements the validation of the event data against the JSON Schema referenced by the dataschema
+attribute.
+
+Schemas are resolved through a Loader: an in-process SchemaRegistry, the local filesystem with FileLoader or HTTP
+with HTTPLoader. The Validator caches the compiled schemas, and can be plugged in the client both on the send path
+and on the receive path using client.WithEventValidator.
+*/
+package jsonschema
@@ -0,0 +1,139 @@
+package jsonschema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrSchemaNotFound is returned by a Loader that cannot resolve a schema URI.
+// Loaders chained with ChainLoader fall through to the next one on this error.
+var ErrSchemaNotFound = errors.New("schema not found")
+
+// Loader resolves a dataschema URI to the raw JSON Schema document.
+type Loader interface {
+	Load(ctx context.Context, uri string) ([]byte, error)
+}
+
+// LoaderFunc is a type alias to implement a Loader through a function pointer.
+type LoaderFunc func(ctx context.Context, uri string) ([]byte, error)
+
+// Load implements Loader.Load
+func (f LoaderFunc) Load(ctx context.Context, uri string) ([]byte, error) {
+	return f(ctx, uri)
+}
+
+// ChainLoader tries each Loader in order, until one of them doesn't return
+// ErrSchemaNotFound.
+type ChainLoader []Loader
+
+// Load implements Loader.Load
+func (c ChainLoader) Load(ctx context.Context, uri string) ([]byte, error) {
+	for _, l := range c {
+		b, err := l.Load(ctx, uri)
+		if errors.Is(err, ErrSchemaNotFound) {
+			continue
+		}
+		return b, err
+	}
+	return nil, fmt.Errorf("%w: %q", ErrSchemaNotFound, uri)
+}
+
+// SchemaRegistry is an in-process Loader holding schemas registered by URI.
+type SchemaRegistry struct {
+	mu      sync.RWMutex
+	schemas map[string][]byte
+}
+
+// NewSchemaRegistry returns an empty SchemaRegistry.
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{schemas: make(map[string][]byte)}
+}
+
+// Register adds the schema document for the given dataschema URI.
+func (r *SchemaRegistry) Register(uri string, schema []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemas[uri] = schema
+}
+
+// Load implements Loader.Load
+func (r *SchemaRegistry) Load(_ context.Context, uri string) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if b, ok := r.schemas[uri]; ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrSchemaNotFound, uri)
+}
+
+// FileLoader loads schemas from the local filesystem.
+// `file` URIs are read from their path. If BaseURI is set, URIs having it as
+// prefix are read from Dir, joined with the rest of the URI path.
+// e.g. with BaseURI "https://example.com/schemas/" and Dir "/etc/schemas",
+// "https://example.com/schemas/order.json" is read from "/etc/schemas/order.json".
+type FileLoader struct {
+	BaseURI string
+	Dir     string
+}
+
+// Load implements Loader.Load
+func (l *FileLoader) Load(_ context.Context, uri string) ([]byte, error) {
+	var path string
+	switch {
+	case l.BaseURI != "" && strings.HasPrefix(uri, l.BaseURI):
+		path = filepath.Join(l.Dir, filepath.FromSlash(strings.TrimPrefix(uri, l.BaseURI)))
+	case strings.HasPrefix(uri, "file:"):
+		u, err := url.Parse(uri)
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.FromSlash(u.Path)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrSchemaNotFound, uri)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// HTTPLoader fetches http and https schema URIs.
+// Use it together with the Validator cache, to avoid fetching a schema on
+// every event.
+type HTTPLoader struct {
+	// Client is used to perform the requests. If nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Load implements Loader.Load
+func (l *HTTPLoader) Load(ctx context.Context, uri string) ([]byte, error) {
+	if !strings.HasPrefix(uri, "http://") && !strings.HasPrefix(uri, "https://") {
+		return nil, fmt.Errorf("%w: %q", ErrSchemaNotFound, uri)
+	}
+	client := l.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %q", ErrSchemaNotFound, uri)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to fetch schema %q: %s", uri, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,171 @@
+package jsonschema
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+const (
+	dataschemaField = "dataschema"
+	dataField       = "data"
+)
+
+type cachedSchema struct {
+	schema  *gojsonschema.Schema
+	expires time.Time
+}
+
+// Validator validates the data of events against the JSON Schema referenced
+// by their dataschema attribute.
+//
+// Failures are reported as event.ValidationError, so they are handled like
+// any other validation failure by the client and the protocols, e.g. the http
+// Protocol replies with 400 Bad Request.
+type Validator struct {
+	loader Loader
+	ttl    time.Duration
+
+	mu    sync.RWMutex
+	cache map[string]cachedSchema
+
+	requireSchema bool
+}
+
+// Option is the function signature for Validator options.
+type Option func(*Validator)
+
+// WithCacheTTL sets how long a compiled schema is cached before being loaded
+// again. By default schemas are cached forever.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(v *Validator) {
+		v.ttl = ttl
+	}
+}
+
+// WithRequiredSchema makes the Validator fail events with JSON data that
+// don't carry a dataschema attribute.
+func WithRequiredSchema() Option {
+	return func(v *Validator) {
+		v.requireSchema = true
+	}
+}
+
+// NewValidator returns a Validator resolving the schemas with loader.
+// Use a ChainLoader to resolve schemas from several sources, e.g.
+//
+//	jsonschema.NewValidator(jsonschema.ChainLoader{registry, &jsonschema.FileLoader{...}, &jsonschema.HTTPLoader{}})
+func NewValidator(loader Loader, opts ...Option) *Validator {
+	v := &Validator{
+		loader: loader,
+		cache:  make(map[string]cachedSchema),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// ValidateEvent validates the data of e against the schema referenced by its
+// dataschema attribute. Events without data, or with a non JSON data content
+// type, are skipped.
+// The signature matches client.EventValidator, so the Validator can be
+// plugged in the client using client.WithEventValidator(v.ValidateEvent).
+func (v *Validator) ValidateEvent(ctx context.Context, e event.Event) error {
+	if e.Context == nil || !isJSON(e.DataMediaType()) {
+		return nil
+	}
+	data, err := jsonData(e)
+	if err != nil {
+		return event.ValidationError{dataField: err}
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	uri := e.DataSchema()
+	if uri == "" {
+		if v.requireSchema {
+			return event.ValidationError{dataschemaField: errors.New("REQUIRED to validate the event data")}
+		}
+		return nil
+	}
+
+	schema, err := v.schema(ctx, uri)
+	if err != nil {
+		return event.ValidationError{dataschemaField: err}
+	}
+
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return event.ValidationError{dataField: err}
+	}
+	if !result.Valid() {
+		msgs := make([]string, 0, len(result.Errors()))
+		for _, re := range result.Errors() {
+			msgs = append(msgs, re.String())
+		}
+		return event.ValidationError{dataField: fmt.Errorf("does not match schema %q: %s", uri, strings.Join(msgs, "; "))}
+	}
+	return nil
+}
+
+// Invalidate removes the schema for uri from the cache.
+func (v *Validator) Invalidate(uri string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.cache, uri)
+}
+
+func (v *Validator) schema(ctx context.Context, uri string) (*gojsonschema.Schema, error) {
+	v.mu.RLock()
+	cs, ok := v.cache[uri]
+	v.mu.RUnlock()
+	if ok && (cs.expires.IsZero() || time.Now().Before(cs.expires)) {
+		return cs.schema, nil
+	}
+
+	b, err := v.loader.Load(ctx, uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema: %w", err)
+	}
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema %q: %w", uri, err)
+	}
+
+	cs = cachedSchema{schema: schema}
+	if v.ttl > 0 {
+		cs.expires = time.Now().Add(v.ttl)
+	}
+	v.mu.Lock()
+	v.cache[uri] = cs
+	v.mu.Unlock()
+	return schema, nil
+}
+
+func isJSON(mediaType string) bool {
+	switch mediaType {
+	case "", event.ApplicationJSON, event.TextJSON:
+		return true
+	}
+	return strings.HasSuffix(mediaType, "+json")
+}
+
+// jsonData returns the decoded data of e, handling the v0.3 base64 encoding.
+func jsonData(e event.Event) ([]byte, error) {
+	data := e.Data()
+	if e.DeprecatedDataContentEncoding() != event.Base64 || len(data) == 0 {
+		return data, nil
+	}
+	s := strings.Trim(string(data), `"'`)
+	return base64.StdEncoding.DecodeString(s)
+}
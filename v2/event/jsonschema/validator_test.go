@@ -0,0 +1,112 @@
+package jsonschema
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+const orderSchema = `{
+  "type": "object",
+  "properties": {
+    "orderId": {"type": "string"},
+    "quantity": {"type": "integer", "minimum": 1}
+  },
+  "required": ["orderId"]
+}`
+
+func newEvent(t *testing.T, schema string, data interface{}) event.Event {
+	e := event.New()
+	e.SetID("1")
+	e.SetType("com.example.order")
+	e.SetSource("/unit/test")
+	if schema != "" {
+		e.SetDataSchema(schema)
+	}
+	require.NoError(t, e.SetData(event.ApplicationJSON, data))
+	return e
+}
+
+func TestValidator_SchemaRegistry(t *testing.T) {
+	registry := NewSchemaRegistry()
+	registry.Register("https://example.com/order.json", []byte(orderSchema))
+	v := NewValidator(registry)
+
+	require.NoError(t, v.ValidateEvent(context.TODO(), newEvent(t, "https://example.com/order.json", map[string]interface{}{"orderId": "42", "quantity": 1})))
+	require.NoError(t, v.ValidateEvent(context.TODO(), newEvent(t, "", map[string]interface{}{"quantity": 0})))
+
+	err := v.ValidateEvent(context.TODO(), newEvent(t, "https://example.com/order.json", map[string]interface{}{"quantity": 0}))
+	var validationErr event.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Contains(t, validationErr, "data")
+
+	err = v.ValidateEvent(context.TODO(), newEvent(t, "https://example.com/unknown.json", map[string]interface{}{"orderId": "42"}))
+	require.True(t, errors.As(err, &validationErr))
+	require.Contains(t, validationErr, "dataschema")
+}
+
+func TestValidator_RequiredSchema(t *testing.T) {
+	v := NewValidator(NewSchemaRegistry(), WithRequiredSchema())
+	err := v.ValidateEvent(context.TODO(), newEvent(t, "", map[string]interface{}{"orderId": "42"}))
+	var validationErr event.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Contains(t, validationErr, "dataschema")
+}
+
+func TestValidator_SkipNonJSON(t *testing.T) {
+	v := NewValidator(NewSchemaRegistry(), WithRequiredSchema())
+	e := event.New()
+	require.NoError(t, e.SetData(event.TextPlain, "hello"))
+	require.NoError(t, v.ValidateEvent(context.TODO(), e))
+}
+
+func TestValidator_FileLoader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jsonschema")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "order.json"), []byte(orderSchema), 0644))
+
+	v := NewValidator(ChainLoader{
+		NewSchemaRegistry(),
+		&FileLoader{BaseURI: "https://example.com/schemas/", Dir: dir},
+	})
+
+	require.NoError(t, v.ValidateEvent(context.TODO(), newEvent(t, "https://example.com/schemas/order.json", map[string]interface{}{"orderId": "42"})))
+	require.NoError(t, v.ValidateEvent(context.TODO(), newEvent(t, "file://"+filepath.ToSlash(filepath.Join(dir, "order.json")), map[string]interface{}{"orderId": "42"})))
+	require.Error(t, v.ValidateEvent(context.TODO(), newEvent(t, "https://example.com/schemas/order.json", map[string]interface{}{})))
+}
+
+func TestValidator_HTTPLoaderCache(t *testing.T) {
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		if r.URL.Path != "/order.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(orderSchema))
+	}))
+	defer server.Close()
+
+	v := NewValidator(&HTTPLoader{})
+	for i := 0; i < 3; i++ {
+		require.NoError(t, v.ValidateEvent(context.TODO(), newEvent(t, server.URL+"/order.json", map[string]interface{}{"orderId": "42"})))
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	v.Invalidate(server.URL + "/order.json")
+	require.NoError(t, v.ValidateEvent(context.TODO(), newEvent(t, server.URL+"/order.json", map[string]interface{}{"orderId": "42"})))
+	require.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+
+	require.Error(t, v.ValidateEvent(context.TODO(), newEvent(t, server.URL+"/missing.json", map[string]interface{}{"orderId": "42"})))
+}
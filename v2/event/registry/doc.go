@@ -0,0 +1,8 @@
+/*
+Package registry provides a registry mapping CloudEvents types (and optionally dataschema) to Go structs.
+
+Registered types can be used to build events from a struct, with the type, dataschema and data content type
+filled in, and by the client to decode the data of incoming events before invoking typed receivers like
+`func(context.Context, event.Event, *OrderCreated)`.
+*/
+package registry
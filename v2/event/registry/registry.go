@@ -0,0 +1,198 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+// ErrNotRegistered is returned when no Go type is registered for an event type.
+var ErrNotRegistered = errors.New("event type not registered")
+
+// Entry describes how a CloudEvents type maps to a Go struct.
+type Entry struct {
+	// Type is the CloudEvents `type` attribute.
+	Type string
+	// DataSchema is the optional CloudEvents `dataschema` attribute. When set,
+	// incoming events match this entry only if they carry the same dataschema.
+	DataSchema string
+	// DataContentType is the content type used to encode the data when an
+	// event is built from a registered struct. Defaults to application/json.
+	DataContentType string
+	// GoType is the struct type (not a pointer) the data decodes into.
+	GoType reflect.Type
+}
+
+// EntryOption is the function signature for options applied to an Entry
+// during registration.
+type EntryOption func(*Entry)
+
+// WithDataSchema restricts the registration to events carrying the given
+// dataschema, and sets it on events built from the registered struct.
+func WithDataSchema(dataSchema string) EntryOption {
+	return func(e *Entry) {
+		e.DataSchema = dataSchema
+	}
+}
+
+// WithDataContentType sets the content type used to encode events built from
+// the registered struct.
+func WithDataContentType(contentType string) EntryOption {
+	return func(e *Entry) {
+		e.DataContentType = contentType
+	}
+}
+
+type key struct {
+	eventType  string
+	dataSchema string
+}
+
+// Registry maps CloudEvents types (and optionally dataschema) to Go structs.
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	byKey    map[key]*Entry
+	byGoType map[reflect.Type]*Entry
+}
+
+// New returns an empty Registry.
+func New() *Registry {
+	return &Registry{
+		byKey:    make(map[key]*Entry),
+		byGoType: make(map[reflect.Type]*Entry),
+	}
+}
+
+// Register maps eventType to the struct type of obj. obj can be either a
+// struct value or a pointer to a struct, e.g. `OrderCreated{}` or
+// `(*OrderCreated)(nil)`.
+// An event type can be registered once per dataschema. A Go type can be
+// registered to a single event type, but for several dataschemas, e.g. to
+// decode the versions of a schema into the same struct: NewEvent then uses
+// the first registration of the Go type.
+func (r *Registry) Register(eventType string, obj interface{}, opts ...EntryOption) error {
+	if eventType == "" {
+		return errors.New("event type must be a non-empty string")
+	}
+	t, err := structType(obj)
+	if err != nil {
+		return err
+	}
+
+	entry := &Entry{
+		Type:            eventType,
+		DataContentType: event.ApplicationJSON,
+		GoType:          t,
+	}
+	for _, opt := range opts {
+		opt(entry)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := key{eventType: entry.Type, dataSchema: entry.DataSchema}
+	if existing, ok := r.byKey[k]; ok {
+		return fmt.Errorf("event type %q (dataschema %q) is already registered to %s", k.eventType, k.dataSchema, existing.GoType)
+	}
+	if existing, ok := r.byGoType[t]; ok {
+		if existing.Type != entry.Type {
+			return fmt.Errorf("%s is already registered to event type %q", t, existing.Type)
+		}
+	} else {
+		r.byGoType[t] = entry
+	}
+	r.byKey[k] = entry
+	return nil
+}
+
+// MustRegister is like Register but panics if the registration fails.
+func (r *Registry) MustRegister(eventType string, obj interface{}, opts ...EntryOption) {
+	if err := r.Register(eventType, obj, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the Entry registered for the given type and dataschema.
+// If no entry is registered for the exact dataschema, the entry registered
+// without a dataschema is returned, if any.
+func (r *Registry) Lookup(eventType, dataSchema string) (*Entry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if e, ok := r.byKey[key{eventType: eventType, dataSchema: dataSchema}]; ok {
+		return e, true
+	}
+	if dataSchema != "" {
+		if e, ok := r.byKey[key{eventType: eventType}]; ok {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// LookupGoType returns the Entry registered for the struct type of obj. If
+// the type is registered for several dataschemas, the first registration is
+// returned.
+func (r *Registry) LookupGoType(obj interface{}) (*Entry, bool) {
+	t, err := structType(obj)
+	if err != nil {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.byGoType[t]
+	return e, ok
+}
+
+// NewData allocates a new instance of the struct registered for e and decodes
+// the event data into it using event.DataAs. The returned value is a pointer
+// to the registered struct.
+func (r *Registry) NewData(e event.Event) (interface{}, error) {
+	entry, ok := r.Lookup(e.Type(), e.DataSchema())
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotRegistered, e.Type())
+	}
+	data := reflect.New(entry.GoType).Interface()
+	if err := e.DataAs(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// NewEvent returns a new event whose type, dataschema, datacontenttype and
+// data are filled from the registration of obj's struct type.
+// The caller is still responsible for setting the id and the source.
+func (r *Registry) NewEvent(obj interface{}, version ...string) (event.Event, error) {
+	entry, ok := r.LookupGoType(obj)
+	if !ok {
+		return event.Event{}, fmt.Errorf("%w: %T", ErrNotRegistered, obj)
+	}
+	e := event.New(version...)
+	e.SetType(entry.Type)
+	if entry.DataSchema != "" {
+		e.SetDataSchema(entry.DataSchema)
+	}
+	if err := e.SetData(entry.DataContentType, obj); err != nil {
+		return event.Event{}, err
+	}
+	return e, nil
+}
+
+func structType(obj interface{}) (reflect.Type, error) {
+	t := reflect.TypeOf(obj)
+	if t == nil {
+		return nil, errors.New("cannot register a nil type")
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct or a pointer to a struct, got %s", reflect.TypeOf(obj))
+	}
+	return t, nil
+}
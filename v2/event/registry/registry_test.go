@@ -0,0 +1,83 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+type OrderCreated struct {
+	OrderID string `json:"orderId"`
+}
+
+type OrderShipped struct {
+	OrderID string `json:"orderId"`
+}
+
+func TestRegister(t *testing.T) {
+	r := New()
+	require.NoError(t, r.Register("com.example.order.created", OrderCreated{}))
+	require.NoError(t, r.Register("com.example.order.shipped", (*OrderShipped)(nil), WithDataSchema("http://example.com/shipped.json")))
+
+	require.Error(t, r.Register("", OrderCreated{}))
+	require.Error(t, r.Register("com.example.string", "not a struct"))
+	require.Error(t, r.Register("com.example.order.created", struct{ A string }{}))
+	require.Error(t, r.Register("com.example.other", &OrderCreated{}))
+
+	entry, ok := r.Lookup("com.example.order.created", "")
+	require.True(t, ok)
+	require.Equal(t, "OrderCreated", entry.GoType.Name())
+
+	entry, ok = r.Lookup("com.example.order.created", "http://example.com/created.json")
+	require.True(t, ok, "falls back to the entry without dataschema")
+	require.Equal(t, "OrderCreated", entry.GoType.Name())
+
+	_, ok = r.Lookup("com.example.order.shipped", "")
+	require.False(t, ok)
+	_, ok = r.Lookup("com.example.order.shipped", "http://example.com/shipped.json")
+	require.True(t, ok)
+}
+
+func TestNewEventAndNewData(t *testing.T) {
+	r := New()
+	r.MustRegister("com.example.order.shipped", OrderShipped{}, WithDataSchema("http://example.com/shipped.json"))
+
+	e, err := r.NewEvent(&OrderShipped{OrderID: "42"})
+	require.NoError(t, err)
+	require.Equal(t, "com.example.order.shipped", e.Type())
+	require.Equal(t, "http://example.com/shipped.json", e.DataSchema())
+	require.Equal(t, event.ApplicationJSON, e.DataContentType())
+
+	data, err := r.NewData(e)
+	require.NoError(t, err)
+	require.Equal(t, &OrderShipped{OrderID: "42"}, data)
+
+	_, err = r.NewEvent(OrderCreated{})
+	require.True(t, errors.Is(err, ErrNotRegistered))
+
+	e.SetType("com.example.unknown")
+	_, err = r.NewData(e)
+	require.True(t, errors.Is(err, ErrNotRegistered))
+}
+
+func TestRegisterSeveralDataSchemas(t *testing.T) {
+	r := New()
+	require.NoError(t, r.Register("com.example.order.created", OrderCreated{}, WithDataSchema("http://example.com/created/v1.json")))
+	require.NoError(t, r.Register("com.example.order.created", OrderCreated{}, WithDataSchema("http://example.com/created/v2.json")))
+	require.Error(t, r.Register("com.example.order.created", OrderCreated{}, WithDataSchema("http://example.com/created/v2.json")))
+	require.Error(t, r.Register("com.example.order.shipped", OrderCreated{}, WithDataSchema("http://example.com/created/v3.json")))
+
+	for _, schema := range []string{"http://example.com/created/v1.json", "http://example.com/created/v2.json"} {
+		entry, ok := r.Lookup("com.example.order.created", schema)
+		require.True(t, ok)
+		require.Equal(t, "OrderCreated", entry.GoType.Name())
+		require.Equal(t, schema, entry.DataSchema)
+	}
+
+	e, err := r.NewEvent(OrderCreated{OrderID: "42"})
+	require.NoError(t, err)
+	require.Equal(t, "http://example.com/created/v1.json", e.DataSchema())
+}
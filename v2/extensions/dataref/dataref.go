@@ -0,0 +1,185 @@
+package dataref
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions/internal/payload"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+// DataRefExtension is the extension holding the URI of the offloaded data.
+const DataRefExtension = "dataref"
+
+// ErrDataUnavailable is returned by the transformers when the data of the
+// message is not available to them, see payload.ErrDataUnavailable.
+var ErrDataUnavailable = payload.ErrDataUnavailable
+
+// GetDataRef returns the dataref extension of e, if set.
+func GetDataRef(e event.Event) (string, bool) {
+	if v, ok := e.Extensions()[DataRefExtension]; ok {
+		if s, err := types.ToString(v); err == nil && s != "" {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// Offloader moves the data of the events exceeding a threshold to a BlobStore.
+type Offloader struct {
+	store     BlobStore
+	threshold int
+}
+
+// NewOffloader returns an Offloader storing in store the data longer than
+// threshold bytes.
+func NewOffloader(store BlobStore, threshold int) *Offloader {
+	return &Offloader{store: store, threshold: threshold}
+}
+
+// OffloadEvent moves the data of e to the BlobStore if it exceeds the
+// threshold, setting the dataref extension.
+func (o *Offloader) OffloadEvent(ctx context.Context, e *event.Event) error {
+	return o.offload(ctx, (*binding.EventMessage)(e), (*eventWriter)(e))
+}
+
+// Transformer returns a binding.Transformer offloading the data while the
+// event is written, e.g. by a protocol Sender. The transformers don't get the
+// context of the message, so ctx is passed to the BlobStore instead: it
+// usually lives as long as the client, to cancel the pending calls when the
+// client shuts down.
+// It fails with ErrDataUnavailable when the message is directly written
+// without being converted to an event.
+func (o *Offloader) Transformer(ctx context.Context) binding.Transformer {
+	return binding.TransformerFunc(func(reader binding.MessageMetadataReader, writer binding.MessageMetadataWriter) error {
+		return o.offload(ctx, reader, writer)
+	})
+}
+
+func (o *Offloader) offload(ctx context.Context, reader binding.MessageMetadataReader, writer binding.MessageMetadataWriter) error {
+	em, ok := reader.(*binding.EventMessage)
+	if !ok {
+		return ErrDataUnavailable
+	}
+	dw, ok := writer.(payload.Writer)
+	if !ok {
+		return ErrDataUnavailable
+	}
+	e := (*event.Event)(em)
+	if len(e.DataEncoded) <= o.threshold {
+		return nil
+	}
+
+	uri, err := o.store.Put(ctx, objectKey(e), e.DataEncoded)
+	if err != nil {
+		return err
+	}
+	if err := writer.SetExtension(DataRefExtension, uri); err != nil {
+		return err
+	}
+	return dw.SetData(bytes.NewReader(nil))
+}
+
+// objectKey derives the key from source and id, which identify the event,
+// so the redeliveries of the same event overwrite the same object.
+func objectKey(e *event.Event) string {
+	sum := sha256.Sum256([]byte(e.Source() + "\x00" + e.ID()))
+	return hex.EncodeToString(sum[:])
+}
+
+// Inliner fetches from a BlobStore the data of the events carrying the
+// dataref extension.
+type Inliner struct {
+	store BlobStore
+}
+
+// NewInliner returns an Inliner fetching the data from store.
+func NewInliner(store BlobStore) *Inliner {
+	return &Inliner{store: store}
+}
+
+// InlineEvent fetches the data referenced by the dataref extension of e and
+// sets it as event data, removing the extension. Events without dataref, or
+// already carrying data, are left untouched.
+func (i *Inliner) InlineEvent(ctx context.Context, e *event.Event) error {
+	_, hadRef := GetDataRef(*e)
+	if err := i.inline(ctx, (*binding.EventMessage)(e), (*eventWriter)(e)); err != nil {
+		return err
+	}
+	if hadRef && len(e.DataEncoded) > 0 {
+		e.DataBase64 = !payload.IsText(e.DataContentType())
+	}
+	return nil
+}
+
+// Transformer returns a binding.Transformer inlining the data while the
+// message is converted to an event, e.g. with binding.ToEvent. As for
+// Offloader.Transformer, ctx is passed to the BlobStore.
+// It fails with ErrDataUnavailable when the message is directly written
+// without being converted to an event.
+func (i *Inliner) Transformer(ctx context.Context) binding.Transformer {
+	return binding.TransformerFunc(func(reader binding.MessageMetadataReader, writer binding.MessageMetadataWriter) error {
+		return i.inline(ctx, reader, writer)
+	})
+}
+
+func (i *Inliner) inline(ctx context.Context, reader binding.MessageMetadataReader, writer binding.MessageMetadataWriter) error {
+	uri, _ := types.ToString(reader.GetExtension(DataRefExtension))
+	if uri == "" {
+		return nil
+	}
+	em, ok := reader.(*binding.EventMessage)
+	if !ok {
+		return ErrDataUnavailable
+	}
+	dw, ok := writer.(payload.Writer)
+	if !ok {
+		return ErrDataUnavailable
+	}
+	if len(em.DataEncoded) > 0 {
+		return nil
+	}
+
+	data, err := i.store.Get(ctx, uri)
+	if err != nil {
+		return err
+	}
+	if err := dw.SetData(bytes.NewReader(data)); err != nil {
+		return err
+	}
+	return writer.SetExtension(DataRefExtension, nil)
+}
+
+// eventWriter writes the metadata and the data of an event, to share the
+// transformers logic with the event level helpers.
+type eventWriter event.Event
+
+func (w *eventWriter) SetAttribute(attribute spec.Attribute, value interface{}) error {
+	if value == nil {
+		return attribute.Delete(w.Context)
+	}
+	return attribute.Set(w.Context, value)
+}
+
+func (w *eventWriter) SetExtension(name string, value interface{}) error {
+	return w.Context.SetExtension(name, value)
+}
+
+func (w *eventWriter) SetData(data io.Reader) error {
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, data); err != nil {
+		return err
+	}
+	if buf.Len() == 0 {
+		w.DataEncoded = nil
+	} else {
+		w.DataEncoded = buf.Bytes()
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package dataref
+
+import (
+	"context"
+	"errors"
+	nethttp "net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+)
+
+func newTestEvent(t *testing.T, size int) event.Event {
+	e := event.New()
+	e.SetID("1")
+	e.SetType("com.example.report.generated")
+	e.SetSource("/reports")
+	require.NoError(t, e.SetData(event.TextPlain, strings.Repeat("a", size)))
+	return e
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	uri, err := store.Put(ctx, "key", []byte("hello"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(uri, "file:///"))
+
+	data, err := store.Get(ctx, uri)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(data))
+
+	_, err = store.Put(ctx, "../escape", []byte("hello"))
+	require.Error(t, err)
+
+	for _, uri := range []string{"file:///etc/passwd", "http://example.com/key", strings.Replace(uri, "/key", "/../key", 1)} {
+		_, err = store.Get(ctx, uri)
+		require.True(t, errors.Is(err, ErrUnknownURI), "unexpected error for %q: %v", uri, err)
+	}
+}
+
+func TestOffloadInlineEvent(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	offloader := NewOffloader(store, 10)
+	inliner := NewInliner(store)
+
+	t.Run("below threshold", func(t *testing.T) {
+		e := newTestEvent(t, 10)
+		require.NoError(t, offloader.OffloadEvent(ctx, &e))
+		_, ok := GetDataRef(e)
+		require.False(t, ok)
+		require.Len(t, e.Data(), 10)
+	})
+
+	t.Run("above threshold", func(t *testing.T) {
+		e := newTestEvent(t, 11)
+		original := e.Clone()
+
+		require.NoError(t, offloader.OffloadEvent(ctx, &e))
+		uri, ok := GetDataRef(e)
+		require.True(t, ok)
+		require.NotEmpty(t, uri)
+		require.Empty(t, e.Data())
+		require.Equal(t, event.TextPlain, e.DataContentType())
+
+		require.NoError(t, inliner.InlineEvent(ctx, &e))
+		require.Equal(t, original, e)
+	})
+
+	t.Run("unknown dataref", func(t *testing.T) {
+		e := newTestEvent(t, 0)
+		e.SetExtension(DataRefExtension, "file:///etc/passwd")
+		require.True(t, errors.Is(inliner.InlineEvent(ctx, &e), ErrUnknownURI))
+	})
+}
+
+func TestTransformers_AcrossEncodings(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	offloader := NewOffloader(store, 10)
+	inliner := NewInliner(store)
+
+	encodings := map[string]func(ctx context.Context) context.Context{
+		"binary":     binding.WithForceBinary,
+		"structured": binding.WithForceStructured,
+	}
+	for n, encode := range encodings {
+		t.Run(n, func(t *testing.T) {
+			ctx := encode(context.Background())
+			e := newTestEvent(t, 1000)
+
+			req, err := nethttp.NewRequest(nethttp.MethodPost, "http://localhost", nil)
+			require.NoError(t, err)
+			require.NoError(t, http.WriteRequest(ctx, binding.ToMessage(&e), req, offloader.Transformer(ctx)))
+			require.True(t, req.ContentLength < 1000)
+
+			got, err := binding.ToEvent(ctx, http.NewMessageFromHttpRequest(req), inliner.Transformer(ctx))
+			require.NoError(t, err)
+			_, ok := GetDataRef(*got)
+			require.False(t, ok)
+			require.Equal(t, strings.Repeat("a", 1000), string(got.Data()))
+		})
+	}
+}
+
+// ctxStore records the contexts passed to the BlobStore.
+type ctxStore struct {
+	BlobStore
+	contexts []context.Context
+}
+
+func (s *ctxStore) Put(ctx context.Context, key string, data []byte) (string, error) {
+	s.contexts = append(s.contexts, ctx)
+	return s.BlobStore.Put(ctx, key, data)
+}
+
+func (s *ctxStore) Get(ctx context.Context, uri string) ([]byte, error) {
+	s.contexts = append(s.contexts, ctx)
+	return s.BlobStore.Get(ctx, uri)
+}
+
+func TestTransformers_Context(t *testing.T) {
+	fileStore, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	store := &ctxStore{BlobStore: fileStore}
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	e := newTestEvent(t, 1000)
+	req, err := nethttp.NewRequest(nethttp.MethodPost, "http://localhost", nil)
+	require.NoError(t, err)
+	require.NoError(t, http.WriteRequest(context.Background(), binding.ToMessage(&e), req, NewOffloader(store, 10).Transformer(ctx)))
+	_, err = binding.ToEvent(context.Background(), http.NewMessageFromHttpRequest(req), NewInliner(store).Transformer(ctx))
+	require.NoError(t, err)
+
+	require.Len(t, store.contexts, 2)
+	for _, got := range store.contexts {
+		require.Equal(t, "value", got.Value(ctxKey{}))
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	e = newTestEvent(t, 1000)
+	_, err = binding.ToEvent(context.Background(), binding.ToMessage(&e), NewOffloader(&cancelledStore{}, 10).Transformer(cancelled))
+	require.True(t, errors.Is(err, context.Canceled))
+}
+
+// cancelledStore fails with the error of the context.
+type cancelledStore struct {
+	BlobStore
+}
+
+func (s *cancelledStore) Put(ctx context.Context, _ string, _ []byte) (string, error) {
+	return "", ctx.Err()
+}
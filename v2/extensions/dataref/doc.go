@@ -0,0 +1,15 @@
+/*
+Package dataref implements the claim-check pattern with the dataref extension.
+
+When the data of an event exceeds a threshold, the Offloader stores it in a BlobStore and replaces it with the
+dataref extension, holding the URI of the stored object. On the receiving side, the Inliner fetches the data from
+the BlobStore and inlines it back, before the event reaches the receiver. This keeps the messages below the size
+limits of the brokers, e.g. the Kafka message.max.bytes or the NATS max payload.
+
+Both are available at event level and as binding.Transformer, which can be plugged in the client with
+client.WithOutboundTransformers and client.WithInboundTransformers, and work with any protocol binding.
+
+FileStore is a BlobStore backed by a directory of the local filesystem, e.g. a volume shared by the producers and
+the consumers.
+*/
+package dataref
@@ -0,0 +1,96 @@
+package dataref
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnknownURI is returned by a BlobStore asked for a URI it doesn't own.
+var ErrUnknownURI = errors.New("the URI doesn't belong to the blob store")
+
+// BlobStore stores the offloaded event data.
+type BlobStore interface {
+	// Put stores data with the provided key, returning the URI of the stored object.
+	// Putting twice the same key overwrites the object.
+	Put(ctx context.Context, key string, data []byte) (string, error)
+	// Get returns the data of the object with the provided URI.
+	// It returns an error wrapping ErrUnknownURI if the URI doesn't belong to the store.
+	Get(ctx context.Context, uri string) ([]byte, error)
+}
+
+// FileStore is a BlobStore storing the objects as files of a local directory.
+// The objects are referenced by file URIs.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore storing the objects in dir, creating it if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(abs, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: abs}, nil
+}
+
+// Put implements BlobStore.Put
+func (s *FileStore) Put(_ context.Context, key string, data []byte) (string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	// Write to a temporary file first, so readers never see partial objects
+	tmp, err := ioutil.TempFile(s.dir, ".tmp-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return u.String(), nil
+}
+
+// Get implements BlobStore.Get
+func (s *FileStore) Get(_ context.Context, uri string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "file" {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownURI, uri)
+	}
+	path := filepath.FromSlash(u.Path)
+	if _, err := s.path(filepath.Base(path)); err != nil || filepath.Dir(path) != s.dir {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownURI, uri)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// path returns the path of the object with the provided key, refusing keys
+// escaping the store directory.
+func (s *FileStore) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) || strings.HasPrefix(key, ".tmp-") {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+	return filepath.Join(s.dir, key), nil
+}
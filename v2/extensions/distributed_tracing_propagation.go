@@ -0,0 +1,109 @@
+package extensions
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+const (
+	// TraceParentHeader is the W3C trace context header name, used by the
+	// protocol bindings to propagate the trace context of the last hop in
+	// native headers.
+	TraceParentHeader = "traceparent"
+	// TraceStateHeader is the W3C trace state header name.
+	TraceStateHeader = "tracestate"
+)
+
+// TraceContextReader is implemented by the protocol messages carrying the W3C
+// trace context in native headers, like Kafka headers or AMQP application
+// properties.
+type TraceContextReader interface {
+	// ReadTraceContext returns the trace context of the native headers, if set.
+	ReadTraceContext() (DistributedTracingExtension, bool)
+}
+
+type traceContextKey struct{}
+
+// ContextWithTraceContext returns a context holding tc as trace context to
+// propagate, taking precedence over the OpenCensus span of the context. Use it
+// to propagate the spans of other tracing libraries.
+func ContextWithTraceContext(ctx context.Context, tc DistributedTracingExtension) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceContextFromContext returns the trace context set with
+// ContextWithTraceContext, or the one of the OpenCensus span in ctx, if any.
+// Protocol bindings write it in native headers when sending messages.
+func TraceContextFromContext(ctx context.Context) (DistributedTracingExtension, bool) {
+	if tc, ok := ctx.Value(traceContextKey{}).(DistributedTracingExtension); ok && tc.TraceParent != "" {
+		return tc, true
+	}
+	span := trace.FromContext(ctx)
+	if span == nil {
+		return DistributedTracingExtension{}, false
+	}
+	return FromSpanContext(span.SpanContext()), true
+}
+
+// ReadNativeTraceContext returns the trace context of the native headers of m,
+// or of the first Message wrapped by m being a TraceContextReader, if the
+// headers are set.
+func ReadNativeTraceContext(m binding.Message) (DistributedTracingExtension, bool) {
+	for m != nil {
+		if r, ok := m.(TraceContextReader); ok {
+			if tc, ok := r.ReadTraceContext(); ok && tc.TraceParent != "" {
+				return tc, true
+			}
+			break
+		}
+		mw, ok := m.(binding.MessageWrapper)
+		if !ok {
+			break
+		}
+		m = mw.GetWrappedMessage()
+	}
+	return DistributedTracingExtension{}, false
+}
+
+// StartConsumerSpan starts the span processing the event e, received with the
+// message m. Both m and e can be nil.
+//
+// The span is parented to the trace context of the native headers of m, set by
+// the last hop, or otherwise to the distributed tracing extension of e, set by
+// the producer of the event. When the event passed through a bridge, the two
+// differ and the span is linked to the producer span.
+// The caller must end the span.
+func StartConsumerSpan(ctx context.Context, name string, m binding.Message, e *event.Event, opts ...trace.StartOption) (context.Context, *trace.Span) {
+	var producer *DistributedTracingExtension
+	if e != nil {
+		if ext, ok := GetDistributedTracingExtension(*e); ok {
+			producer = &ext
+		}
+	}
+
+	if hop, ok := ReadNativeTraceContext(m); ok {
+		if ctx, span := hop.StartChildSpan(ctx, name, opts...); span != nil {
+			if producer != nil && producer.TraceParent != hop.TraceParent {
+				if sc, err := producer.ToSpanContext(); err == nil {
+					span.AddLink(trace.Link{
+						TraceID: sc.TraceID,
+						SpanID:  sc.SpanID,
+						Type:    trace.LinkTypeParent,
+					})
+				}
+			}
+			return ctx, span
+		}
+	}
+
+	if producer != nil {
+		if ctx, span := producer.StartChildSpan(ctx, name, opts...); span != nil {
+			return ctx, span
+		}
+	}
+	return trace.StartSpan(ctx, name, opts...)
+}
@@ -0,0 +1,83 @@
+package extensions_test
+
+import (
+	"context"
+	nethttp "net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+)
+
+type spanRecorder []*trace.SpanData
+
+func (r *spanRecorder) ExportSpan(s *trace.SpanData) {
+	*r = append(*r, s)
+}
+
+func TestStartConsumerSpan(t *testing.T) {
+	recorder := &spanRecorder{}
+	trace.RegisterExporter(recorder)
+	defer trace.UnregisterExporter(recorder)
+
+	_, producer := trace.StartSpan(context.Background(), "producer", trace.WithSampler(trace.AlwaysSample()))
+	producer.End()
+	_, bridge := trace.StartSpan(context.Background(), "bridge", trace.WithSampler(trace.AlwaysSample()))
+	bridge.End()
+
+	e := event.New()
+	extensions.FromSpanContext(producer.SpanContext()).AddTracingAttributes(&e)
+
+	header := nethttp.Header{}
+	hop := extensions.FromSpanContext(bridge.SpanContext())
+	header.Set(extensions.TraceParentHeader, hop.TraceParent)
+
+	tests := map[string]struct {
+		header     nethttp.Header
+		wrap       bool
+		wantParent trace.SpanContext
+		wantLinks  int
+	}{
+		"extension only": {
+			header:     nethttp.Header{},
+			wantParent: producer.SpanContext(),
+		},
+		"native header": {
+			header:     header,
+			wantParent: bridge.SpanContext(),
+			wantLinks:  1,
+		},
+		"wrapped message with native header": {
+			header:     header,
+			wrap:       true,
+			wantParent: bridge.SpanContext(),
+			wantLinks:  1,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			*recorder = nil
+			var m binding.Message = http.NewMessage(tc.header, nil)
+			if tc.wrap {
+				// Like the messages of the Kafka receiver
+				m = binding.WithFinish(m, nil)
+			}
+			_, span := extensions.StartConsumerSpan(context.Background(), "consumer", m, &e)
+			span.End()
+
+			require.Len(t, *recorder, 1)
+			got := (*recorder)[0]
+			require.Equal(t, tc.wantParent.TraceID, got.TraceID)
+			require.Equal(t, tc.wantParent.SpanID, got.ParentSpanID)
+			require.Len(t, got.Links, tc.wantLinks)
+			if tc.wantLinks > 0 {
+				require.Equal(t, producer.SpanContext().SpanID, got.Links[0].SpanID)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+/*
+Package encryption implements the encryption of the event data, keeping the context attributes readable for routing.
+
+The data is replaced by a JWE (RFC 7516) in compact serialization, with the "application/jose" data content type.
+The original data content type is protected in the JWE header and restored on decryption. The encryptionalg and
+encryptionkid extensions describe the key management algorithm and the key used to encrypt the data, so that
+intermediaries can tell encrypted events apart without parsing the data.
+
+Symmetric keys use direct encryption ("dir") with AES-GCM, RSA public keys use RSA-OAEP-256 to wrap a random AES-256
+content encryption key.
+
+Both the Encrypter and the Decrypter can be used at event level, or as binding.Transformer while writing the event or
+converting a message to an event.
+*/
+package encryption
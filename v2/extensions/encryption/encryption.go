@@ -0,0 +1,230 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions/internal/payload"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+const (
+	// AlgorithmExtension holds the JWE key management algorithm of encrypted events.
+	AlgorithmExtension = "encryptionalg"
+	// KeyIDExtension holds the id of the key used to encrypt the event data.
+	KeyIDExtension = "encryptionkid"
+
+	// JOSEContentType is the data content type of encrypted events.
+	JOSEContentType = "application/jose"
+)
+
+// ErrDataUnavailable is returned by the transformers when the data of the
+// message is not available to them, see payload.ErrDataUnavailable.
+var ErrDataUnavailable = payload.ErrDataUnavailable
+
+// Encrypter encrypts the data of events.
+type Encrypter struct {
+	alg   string
+	enc   string
+	keyID string
+	cek   func() (cek, encryptedKey []byte, err error)
+}
+
+// NewDirectEncrypter returns an Encrypter using directly the symmetric key
+// identified by keyID as AES-GCM key. The key must be 16, 24 or 32 bytes long.
+func NewDirectEncrypter(keyID string, key []byte) (*Encrypter, error) {
+	enc, err := contentEncryption(len(key))
+	if err != nil {
+		return nil, err
+	}
+	return &Encrypter{
+		alg:   Direct,
+		enc:   enc,
+		keyID: keyID,
+		cek: func() ([]byte, []byte, error) {
+			return key, nil, nil
+		},
+	}, nil
+}
+
+// NewRSAEncrypter returns an Encrypter generating a random AES-256 key for
+// each event, wrapped with RSA-OAEP-256 using the public key identified by
+// keyID.
+func NewRSAEncrypter(keyID string, key *rsa.PublicKey) *Encrypter {
+	return &Encrypter{
+		alg:   RSAOAEP256,
+		enc:   A256GCM,
+		keyID: keyID,
+		cek: func() ([]byte, []byte, error) {
+			cek := make([]byte, 32)
+			if _, err := rand.Read(cek); err != nil {
+				return nil, nil, err
+			}
+			encryptedKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, key, cek, nil)
+			return cek, encryptedKey, err
+		},
+	}
+}
+
+// EncryptEvent encrypts the data of e. Events without data, or already
+// encrypted, are left untouched.
+func (e *Encrypter) EncryptEvent(ev *event.Event) error {
+	if _, err := binding.ToEvent(context.Background(), binding.ToMessage(ev), e.Transformer()); err != nil {
+		return err
+	}
+	if isEncrypted(ev) {
+		// The JWE compact serialization is text
+		ev.DataBase64 = false
+	}
+	return nil
+}
+
+// Transformer returns a binding.Transformer encrypting the data while the
+// event is written, e.g. by a protocol Sender.
+// It fails with ErrDataUnavailable when the message is directly written
+// without being converted to an event.
+func (e *Encrypter) Transformer() binding.Transformer {
+	return binding.TransformerFunc(func(reader binding.MessageMetadataReader, writer binding.MessageMetadataWriter) error {
+		em, ok := reader.(*binding.EventMessage)
+		if !ok {
+			return ErrDataUnavailable
+		}
+		dw, ok := writer.(payload.Writer)
+		if !ok {
+			return ErrDataUnavailable
+		}
+		ev := (*event.Event)(em)
+		if len(ev.DataEncoded) == 0 || isEncrypted(ev) {
+			return nil
+		}
+
+		cek, encryptedKey, err := e.cek()
+		if err != nil {
+			return err
+		}
+		h := header{Alg: e.alg, Enc: e.enc, Kid: e.keyID, Cty: ev.DataContentType()}
+		ciphertext, err := seal(h, encryptedKey, cek, ev.DataEncoded)
+		if err != nil {
+			return err
+		}
+
+		attr, _ := reader.GetAttribute(spec.DataContentType)
+		if err := writer.SetAttribute(attr, JOSEContentType); err != nil {
+			return err
+		}
+		if err := writer.SetExtension(AlgorithmExtension, e.alg); err != nil {
+			return err
+		}
+		if e.keyID != "" {
+			if err := writer.SetExtension(KeyIDExtension, e.keyID); err != nil {
+				return err
+			}
+		}
+		return dw.SetData(bytes.NewReader(ciphertext))
+	})
+}
+
+// KeyResolver returns the decryption key identified by keyID: a []byte for
+// direct encryption or an *rsa.PrivateKey for RSA-OAEP-256.
+type KeyResolver func(keyID string) (interface{}, error)
+
+// StaticKeys returns a KeyResolver looking up the keys by id in keys.
+func StaticKeys(keys map[string]interface{}) KeyResolver {
+	return func(keyID string) (interface{}, error) {
+		if k, ok := keys[keyID]; ok {
+			return k, nil
+		}
+		return nil, fmt.Errorf("%w: unknown key %q", ErrDecryption, keyID)
+	}
+}
+
+// Decrypter decrypts the data of events encrypted by an Encrypter.
+type Decrypter struct {
+	resolve KeyResolver
+}
+
+// NewDecrypter returns a Decrypter resolving the decryption keys with keys.
+func NewDecrypter(keys KeyResolver) *Decrypter {
+	return &Decrypter{resolve: keys}
+}
+
+// DecryptEvent decrypts the data of e, restoring the original data content
+// type. Events not encrypted are left untouched.
+func (d *Decrypter) DecryptEvent(ev *event.Event) error {
+	wasEncrypted := isEncrypted(ev)
+	if _, err := binding.ToEvent(context.Background(), binding.ToMessage(ev), d.Transformer()); err != nil {
+		return err
+	}
+	if wasEncrypted {
+		ev.DataBase64 = !payload.IsText(ev.DataContentType())
+	}
+	return nil
+}
+
+// Transformer returns a binding.Transformer decrypting the data while the
+// message is converted to an event, e.g. with binding.ToEvent.
+// It fails with ErrDataUnavailable when the message is directly written
+// without being converted to an event.
+func (d *Decrypter) Transformer() binding.Transformer {
+	return binding.TransformerFunc(func(reader binding.MessageMetadataReader, writer binding.MessageMetadataWriter) error {
+		if alg, _ := types.ToString(reader.GetExtension(AlgorithmExtension)); alg == "" {
+			return nil
+		}
+		em, ok := reader.(*binding.EventMessage)
+		if !ok {
+			return ErrDataUnavailable
+		}
+		dw, ok := writer.(payload.Writer)
+		if !ok {
+			return ErrDataUnavailable
+		}
+		ev := (*event.Event)(em)
+		if !isEncrypted(ev) {
+			return nil
+		}
+
+		j, err := parse(ev.DataEncoded)
+		if err != nil {
+			return err
+		}
+		key, err := d.resolve(j.header.Kid)
+		if err != nil {
+			return err
+		}
+		plaintext, err := j.open(key)
+		if err != nil {
+			return err
+		}
+
+		attr, _ := reader.GetAttribute(spec.DataContentType)
+		var contentType interface{}
+		if j.header.Cty != "" {
+			contentType = j.header.Cty
+		}
+		if err := writer.SetAttribute(attr, contentType); err != nil {
+			return err
+		}
+		if err := writer.SetExtension(AlgorithmExtension, nil); err != nil {
+			return err
+		}
+		if err := writer.SetExtension(KeyIDExtension, nil); err != nil {
+			return err
+		}
+		return dw.SetData(bytes.NewReader(plaintext))
+	})
+}
+
+func isEncrypted(ev *event.Event) bool {
+	if ev.DataMediaType() != JOSEContentType {
+		return false
+	}
+	alg, err := types.ToString(ev.Extensions()[AlgorithmExtension])
+	return err == nil && alg != ""
+}
@@ -0,0 +1,134 @@
+package encryption
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	nethttp "net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+)
+
+type testKeys struct {
+	name      string
+	encrypter *Encrypter
+	decrypter *Decrypter
+}
+
+func newTestKeys(t *testing.T) []testKeys {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	direct, err := NewDirectEncrypter("sym", secret)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keys := StaticKeys(map[string]interface{}{
+		"sym": secret,
+		"rsa": rsaKey,
+	})
+	return []testKeys{
+		{name: "dir", encrypter: direct, decrypter: NewDecrypter(keys)},
+		{name: "RSA-OAEP-256", encrypter: NewRSAEncrypter("rsa", &rsaKey.PublicKey), decrypter: NewDecrypter(keys)},
+	}
+}
+
+func newTestEvent(t *testing.T) event.Event {
+	e := event.New()
+	e.SetID("1")
+	e.SetType("com.example.payment.created")
+	e.SetSource("/payments")
+	require.NoError(t, e.SetData(event.ApplicationJSON, map[string]string{"card": "4111111111111111"}))
+	return e
+}
+
+func TestEncryptDecryptEvent(t *testing.T) {
+	for _, k := range newTestKeys(t) {
+		t.Run(k.name, func(t *testing.T) {
+			e := newTestEvent(t)
+			original := e.Clone()
+
+			require.NoError(t, k.encrypter.EncryptEvent(&e))
+			require.Equal(t, JOSEContentType, e.DataContentType())
+			require.Equal(t, k.encrypter.alg, e.Extensions()[AlgorithmExtension])
+			require.Equal(t, k.encrypter.keyID, e.Extensions()[KeyIDExtension])
+			require.NotContains(t, string(e.Data()), "4111111111111111")
+			require.Equal(t, original.Type(), e.Type())
+
+			require.NoError(t, k.decrypter.DecryptEvent(&e))
+			require.Equal(t, original, e)
+		})
+	}
+}
+
+func TestDecryptEvent_Tampered(t *testing.T) {
+	for _, k := range newTestKeys(t) {
+		t.Run(k.name, func(t *testing.T) {
+			e := newTestEvent(t)
+			require.NoError(t, k.encrypter.EncryptEvent(&e))
+			parts := strings.Split(string(e.Data()), ".")
+			ciphertext, err := base64.RawURLEncoding.DecodeString(parts[3])
+			require.NoError(t, err)
+			ciphertext[0] ^= 0xff
+			parts[3] = base64.RawURLEncoding.EncodeToString(ciphertext)
+			e.DataEncoded = []byte(strings.Join(parts, "."))
+
+			err = k.decrypter.DecryptEvent(&e)
+			require.True(t, errors.Is(err, ErrDecryption), "unexpected error %v", err)
+		})
+	}
+}
+
+func TestDecryptEvent_WrongKey(t *testing.T) {
+	e := newTestEvent(t)
+	encrypter, err := NewDirectEncrypter("sym", []byte("0123456789abcdef"))
+	require.NoError(t, err)
+	require.NoError(t, encrypter.EncryptEvent(&e))
+
+	decrypter := NewDecrypter(StaticKeys(map[string]interface{}{"sym": []byte("fedcba9876543210")}))
+	require.True(t, errors.Is(decrypter.DecryptEvent(&e), ErrDecryption))
+
+	decrypter = NewDecrypter(StaticKeys(nil))
+	require.True(t, errors.Is(decrypter.DecryptEvent(&e), ErrDecryption))
+}
+
+func TestDecryptEvent_NotEncrypted(t *testing.T) {
+	e := newTestEvent(t)
+	original := e.Clone()
+	require.NoError(t, NewDecrypter(StaticKeys(nil)).DecryptEvent(&e))
+	require.Equal(t, original, e)
+}
+
+func TestTransformers_AcrossEncodings(t *testing.T) {
+	encodings := map[string]func(ctx context.Context) context.Context{
+		"binary":     binding.WithForceBinary,
+		"structured": binding.WithForceStructured,
+	}
+	for _, k := range newTestKeys(t) {
+		for n, encode := range encodings {
+			t.Run(k.name+"/"+n, func(t *testing.T) {
+				ctx := encode(context.Background())
+				e := newTestEvent(t)
+
+				req, err := nethttp.NewRequest(nethttp.MethodPost, "http://localhost", nil)
+				require.NoError(t, err)
+				require.NoError(t, http.WriteRequest(ctx, binding.ToMessage(&e), req, k.encrypter.Transformer()))
+
+				got, err := binding.ToEvent(ctx, http.NewMessageFromHttpRequest(req), k.decrypter.Transformer())
+				require.NoError(t, err)
+				require.Equal(t, event.ApplicationJSON, got.DataContentType())
+				require.Nil(t, got.Extensions()[AlgorithmExtension])
+				require.Nil(t, got.Extensions()[KeyIDExtension])
+				require.JSONEq(t, `{"card":"4111111111111111"}`, string(got.Data()))
+			})
+		}
+	}
+}
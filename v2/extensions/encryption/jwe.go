@@ -0,0 +1,161 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// JWE key management algorithms supported by Encrypter and Decrypter.
+const (
+	Direct     = "dir"
+	RSAOAEP256 = "RSA-OAEP-256"
+)
+
+// JWE content encryption algorithms supported by Encrypter and Decrypter.
+const (
+	A128GCM = "A128GCM"
+	A192GCM = "A192GCM"
+	A256GCM = "A256GCM"
+)
+
+// ErrDecryption is returned when the data cannot be decrypted.
+var ErrDecryption = errors.New("cannot decrypt event data")
+
+// header is the JWE protected header. Cty holds the original data content type.
+type header struct {
+	Alg string `json:"alg"`
+	Enc string `json:"enc"`
+	Kid string `json:"kid,omitempty"`
+	Cty string `json:"cty,omitempty"`
+}
+
+func contentEncryption(keySize int) (string, error) {
+	switch keySize {
+	case 16:
+		return A128GCM, nil
+	case 24:
+		return A192GCM, nil
+	case 32:
+		return A256GCM, nil
+	}
+	return "", fmt.Errorf("invalid AES key size %d", keySize)
+}
+
+// seal encrypts plaintext with cek, returning the JWE compact serialization.
+func seal(h header, encryptedKey, cek, plaintext []byte) ([]byte, error) {
+	hb, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	protected := base64.RawURLEncoding.EncodeToString(hb)
+
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, err
+	}
+	iv := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	sealed := gcm.Seal(nil, iv, plaintext, []byte(protected))
+	ciphertext, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+
+	return []byte(strings.Join([]string{
+		protected,
+		base64.RawURLEncoding.EncodeToString(encryptedKey),
+		base64.RawURLEncoding.EncodeToString(iv),
+		base64.RawURLEncoding.EncodeToString(ciphertext),
+		base64.RawURLEncoding.EncodeToString(tag),
+	}, ".")), nil
+}
+
+type jwe struct {
+	header       header
+	protected    string
+	encryptedKey []byte
+	iv           []byte
+	ciphertext   []byte
+	tag          []byte
+}
+
+func parse(compact []byte) (*jwe, error) {
+	parts := strings.Split(string(compact), ".")
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("%w: malformed JWE", ErrDecryption)
+	}
+	decoded := make([][]byte, 5)
+	for i, p := range parts {
+		b, err := base64.RawURLEncoding.DecodeString(p)
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed JWE: %v", ErrDecryption, err)
+		}
+		decoded[i] = b
+	}
+	j := &jwe{
+		protected:    parts[0],
+		encryptedKey: decoded[1],
+		iv:           decoded[2],
+		ciphertext:   decoded[3],
+		tag:          decoded[4],
+	}
+	if err := json.Unmarshal(decoded[0], &j.header); err != nil {
+		return nil, fmt.Errorf("%w: malformed JWE header: %v", ErrDecryption, err)
+	}
+	return j, nil
+}
+
+// open decrypts the JWE with the key, which must match the key management
+// algorithm declared in the header.
+func (j *jwe) open(key interface{}) ([]byte, error) {
+	var cek []byte
+	switch k := key.(type) {
+	case []byte:
+		if j.header.Alg != Direct {
+			return nil, fmt.Errorf("%w: algorithm %q doesn't match a symmetric key", ErrDecryption, j.header.Alg)
+		}
+		cek = k
+	case *rsa.PrivateKey:
+		if j.header.Alg != RSAOAEP256 {
+			return nil, fmt.Errorf("%w: algorithm %q doesn't match an RSA key", ErrDecryption, j.header.Alg)
+		}
+		var err error
+		cek, err = rsa.DecryptOAEP(sha256.New(), rand.Reader, k, j.encryptedKey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrDecryption, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported decryption key type %T", key)
+	}
+
+	if enc, err := contentEncryption(len(cek)); err != nil || enc != j.header.Enc {
+		return nil, fmt.Errorf("%w: content encryption %q doesn't match the key", ErrDecryption, j.header.Enc)
+	}
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, err
+	}
+	if len(j.iv) != gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid initialization vector", ErrDecryption)
+	}
+	plaintext, err := gcm.Open(nil, j.iv, append(j.ciphertext, j.tag...), []byte(j.protected))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryption, err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
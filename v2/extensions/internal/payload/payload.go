@@ -0,0 +1,37 @@
+// Package payload provides the helpers shared by the extensions transforming
+// or reading the data of the events.
+package payload
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+// ErrDataUnavailable is returned by the transformers when the data of the
+// message cannot be read or replaced, e.g. when a binary message is directly
+// written to another protocol. Use them with binding.ToEvent, or on the send
+// path of an event, where the data is always available.
+var ErrDataUnavailable = errors.New("the data of the message is not available to the transformer")
+
+// Writer is implemented by the message writers able to replace the data.
+type Writer interface {
+	SetData(data io.Reader) error
+}
+
+// IsText reports whether the data with the given content type is text, so it
+// can be carried by the JSON format without base64 encoding.
+func IsText(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") || mediaType == event.ApplicationJSON ||
+		mediaType == event.ApplicationXML || strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml")
+}
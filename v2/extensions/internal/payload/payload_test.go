@@ -0,0 +1,23 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsText(t *testing.T) {
+	for contentType, want := range map[string]bool{
+		"":                          true,
+		"text/plain; charset=utf-8": true,
+		"application/json":          true,
+		"application/xml":           true,
+		"application/vnd.api+json":  true,
+		"application/atom+xml":      true,
+		"application/octet-stream":  false,
+		"image/png":                 false,
+		"not a ; content type":      false,
+	} {
+		require.Equal(t, want, IsText(contentType), contentType)
+	}
+}
@@ -0,0 +1,42 @@
+package extensions
+
+import (
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+// PartitionKeyExtension is the partitioning extension, holding the key used to
+// group the related events in the same partition, ordering key or message group
+// of the protocols supporting it.
+const PartitionKeyExtension = "partitionkey"
+
+// SetPartitionKey sets the partitionkey extension of e. An empty key removes it.
+func SetPartitionKey(e *event.Event, key string) {
+	if key == "" {
+		e.SetExtension(PartitionKeyExtension, nil)
+		return
+	}
+	e.SetExtension(PartitionKeyExtension, key)
+}
+
+// GetPartitionKey returns the partitionkey extension of e, if set.
+func GetPartitionKey(e event.Event) (string, bool) {
+	if v, ok := e.Extensions()[PartitionKeyExtension]; ok {
+		if s, err := types.Format(v); err == nil && s != "" {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// ReadPartitionKey returns the partitionkey extension of a message, or an empty
+// string if it's not set. Protocol bindings use it to map the extension to
+// their native partitioning mechanism.
+func ReadPartitionKey(reader binding.MessageMetadataReader) (string, error) {
+	v := reader.GetExtension(PartitionKeyExtension)
+	if types.IsZero(v) {
+		return "", nil
+	}
+	return types.Format(v)
+}
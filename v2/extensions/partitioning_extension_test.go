@@ -0,0 +1,34 @@
+package extensions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+)
+
+func TestPartitionKey(t *testing.T) {
+	e := event.New()
+	_, ok := extensions.GetPartitionKey(e)
+	require.False(t, ok)
+
+	extensions.SetPartitionKey(&e, "key")
+	key, ok := extensions.GetPartitionKey(e)
+	require.True(t, ok)
+	require.Equal(t, "key", key)
+
+	key, err := extensions.ReadPartitionKey(binding.ToMessage(&e).(binding.MessageMetadataReader))
+	require.NoError(t, err)
+	require.Equal(t, "key", key)
+
+	extensions.SetPartitionKey(&e, "")
+	_, ok = extensions.GetPartitionKey(e)
+	require.False(t, ok)
+
+	key, err = extensions.ReadPartitionKey(binding.ToMessage(&e).(binding.MessageMetadataReader))
+	require.NoError(t, err)
+	require.Empty(t, key)
+}
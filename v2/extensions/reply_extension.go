@@ -0,0 +1,53 @@
+package extensions
+
+import (
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+const (
+	// ReplyToExtension holds the destination of the response to an event,
+	// e.g. a topic, a subject or a URL, depending on the protocol.
+	ReplyToExtension = "replyto"
+
+	// CorrelationIDExtension holds the id of the event a response answers.
+	CorrelationIDExtension = "correlationid"
+)
+
+// SetReplyTo sets the replyto extension of e. An empty destination removes it.
+func SetReplyTo(e *event.Event, destination string) {
+	setStringExtension(e, ReplyToExtension, destination)
+}
+
+// GetReplyTo returns the replyto extension of e, if set.
+func GetReplyTo(e event.Event) (string, bool) {
+	return getStringExtension(e, ReplyToExtension)
+}
+
+// SetCorrelationID sets the correlationid extension of e. An empty id removes
+// it.
+func SetCorrelationID(e *event.Event, id string) {
+	setStringExtension(e, CorrelationIDExtension, id)
+}
+
+// GetCorrelationID returns the correlationid extension of e, if set.
+func GetCorrelationID(e event.Event) (string, bool) {
+	return getStringExtension(e, CorrelationIDExtension)
+}
+
+func setStringExtension(e *event.Event, name, value string) {
+	if value == "" {
+		e.SetExtension(name, nil)
+		return
+	}
+	e.SetExtension(name, value)
+}
+
+func getStringExtension(e event.Event, name string) (string, bool) {
+	if v, ok := e.Extensions()[name]; ok {
+		if s, err := types.Format(v); err == nil && s != "" {
+			return s, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,40 @@
+package extensions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+)
+
+func TestReplyTo(t *testing.T) {
+	e := event.New()
+	_, ok := extensions.GetReplyTo(e)
+	require.False(t, ok)
+
+	extensions.SetReplyTo(&e, "replies")
+	destination, ok := extensions.GetReplyTo(e)
+	require.True(t, ok)
+	require.Equal(t, "replies", destination)
+
+	extensions.SetReplyTo(&e, "")
+	_, ok = extensions.GetReplyTo(e)
+	require.False(t, ok)
+}
+
+func TestCorrelationID(t *testing.T) {
+	e := event.New()
+	_, ok := extensions.GetCorrelationID(e)
+	require.False(t, ok)
+
+	extensions.SetCorrelationID(&e, "id")
+	id, ok := extensions.GetCorrelationID(e)
+	require.True(t, ok)
+	require.Equal(t, "id", id)
+
+	extensions.SetCorrelationID(&e, "")
+	_, ok = extensions.GetCorrelationID(e)
+	require.False(t, ok)
+}
@@ -0,0 +1,107 @@
+package extensions
+
+import (
+	"hash/fnv"
+	"math"
+	"strconv"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+// SampledRateExtension is the sampling extension, holding the number of
+// similar events each sampled event stands for, including itself.
+const SampledRateExtension = "sampledrate"
+
+// SetSampledRate sets the sampledrate extension of e. A rate lower than 2,
+// meaning no sampling, removes it.
+func SetSampledRate(e *event.Event, rate int32) {
+	if rate < 2 {
+		e.SetExtension(SampledRateExtension, nil)
+		return
+	}
+	e.SetExtension(SampledRateExtension, rate)
+}
+
+// GetSampledRate returns the sampledrate extension of e, if set to a positive
+// integer.
+func GetSampledRate(e event.Event) (int32, bool) {
+	v, ok := e.Extensions()[SampledRateExtension]
+	if !ok {
+		return 0, false
+	}
+	rate, err := types.ToInteger(v)
+	if err != nil || rate < 1 {
+		return 0, false
+	}
+	return rate, true
+}
+
+// Sampler keeps one event out of rate, choosing the events by a hash of their
+// id and upstream sampledrate, so that the decision for an event is the same
+// across processes and retries.
+type Sampler struct {
+	rate      int32
+	typeRates map[string]int32
+}
+
+// NewSampler returns a Sampler with rate as sampling rate of the events, and
+// typeRates as sampling rates of specific event types. A rate lower than 2
+// keeps all the events.
+func NewSampler(rate int32, typeRates map[string]int32) *Sampler {
+	s := &Sampler{rate: rate, typeRates: make(map[string]int32, len(typeRates))}
+	for t, r := range typeRates {
+		s.typeRates[t] = r
+	}
+	return s
+}
+
+// Rate returns the sampling rate of e.
+func (s *Sampler) Rate(e event.Event) int32 {
+	if r, ok := s.typeRates[e.Type()]; ok {
+		return r
+	}
+	return s.rate
+}
+
+// Sample reports whether e is kept. The kept events carry the effective rate in
+// the sampledrate extension: events already sampled upstream get their rate
+// multiplied, so the counts can be rescaled with a single factor.
+func (s *Sampler) Sample(e *event.Event) bool {
+	rate := s.Rate(*e)
+	if rate < 2 {
+		return true
+	}
+	// The upstream rate salts the hash: the samplers of a chain see increasing
+	// upstream rates, so their decisions are independent and the rates multiply
+	upstream, _ := GetSampledRate(*e)
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(e.ID()))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(strconv.Itoa(int(upstream))))
+	if mix64(h.Sum64())%uint64(rate) != 0 {
+		return false
+	}
+
+	effective := int64(rate)
+	if upstream > 0 {
+		effective *= int64(upstream)
+	}
+	if effective > math.MaxInt32 {
+		effective = math.MaxInt32
+	}
+	SetSampledRate(e, int32(effective))
+	return true
+}
+
+// mix64 is the finalizer of MurmurHash3. The low bits of FNV depend only on the
+// low bits of the input bytes: mixing in the high bits makes the decisions with
+// different salts independent.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
@@ -0,0 +1,94 @@
+package extensions_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+)
+
+func TestSampledRate(t *testing.T) {
+	e := event.New()
+	_, ok := extensions.GetSampledRate(e)
+	require.False(t, ok)
+
+	extensions.SetSampledRate(&e, 10)
+	rate, ok := extensions.GetSampledRate(e)
+	require.True(t, ok)
+	require.Equal(t, int32(10), rate)
+
+	e.SetExtension(extensions.SampledRateExtension, "20")
+	rate, ok = extensions.GetSampledRate(e)
+	require.True(t, ok)
+	require.Equal(t, int32(20), rate)
+
+	extensions.SetSampledRate(&e, 1)
+	_, ok = extensions.GetSampledRate(e)
+	require.False(t, ok)
+}
+
+func TestSampler(t *testing.T) {
+	sampler := extensions.NewSampler(10, map[string]int32{"all": 1})
+	newEvent := func(id, eventType string) event.Event {
+		e := event.New()
+		e.SetID(id)
+		e.SetType(eventType)
+		return e
+	}
+
+	kept := 0
+	for i := 0; i < 1000; i++ {
+		e := newEvent(fmt.Sprintf("id-%d", i), "some")
+		if !sampler.Sample(&e) {
+			_, ok := extensions.GetSampledRate(e)
+			require.False(t, ok)
+			continue
+		}
+		kept++
+		rate, _ := extensions.GetSampledRate(e)
+		require.Equal(t, int32(10), rate)
+
+		// The decision is deterministic by id
+		again := newEvent(e.ID(), "some")
+		require.True(t, sampler.Sample(&again))
+
+		all := newEvent(e.ID(), "all")
+		require.True(t, sampler.Sample(&all))
+		_, ok := extensions.GetSampledRate(all)
+		require.False(t, ok)
+	}
+	require.InDelta(t, 100, kept, 40)
+
+	// Rates multiply with the upstream sampling
+	for i := 0; ; i++ {
+		e := newEvent(fmt.Sprintf("id-%d", i), "some")
+		extensions.SetSampledRate(&e, 3)
+		if sampler.Sample(&e) {
+			rate, _ := extensions.GetSampledRate(e)
+			require.Equal(t, int32(30), rate)
+			break
+		}
+	}
+}
+
+func TestSampler_Chained(t *testing.T) {
+	first := extensions.NewSampler(2, nil)
+	second := extensions.NewSampler(4, nil)
+
+	kept := 0
+	for i := 0; i < 8000; i++ {
+		e := event.New()
+		e.SetID(fmt.Sprintf("id-%d", i))
+		if !first.Sample(&e) || !second.Sample(&e) {
+			continue
+		}
+		kept++
+		rate, _ := extensions.GetSampledRate(e)
+		require.Equal(t, int32(8), rate)
+	}
+	// The decisions are independent: the stamped rate matches the kept events
+	require.InDelta(t, 1000, kept, 150)
+}
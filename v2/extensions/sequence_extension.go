@@ -0,0 +1,56 @@
+package extensions
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+const (
+	SequenceExtension = "sequence"
+
+	// sequenceDigits is the number of digits of the largest uint64, so that the
+	// formatted sequences are lexicographically sortable.
+	sequenceDigits = 20
+)
+
+// FormatSequence formats n as a zero padded decimal, so that the sequences
+// compare lexicographically as the CloudEvents sequence extension requires.
+func FormatSequence(n uint64) string {
+	return fmt.Sprintf("%0*d", sequenceDigits, n)
+}
+
+// ParseSequence parses a decimal sequence, zero padded or not.
+func ParseSequence(s string) (uint64, error) {
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// SetSequence sets the sequence extension of e to the formatted n.
+func SetSequence(e *event.Event, n uint64) {
+	e.SetExtension(SequenceExtension, FormatSequence(n))
+}
+
+// GetSequence returns the sequence extension of e, if it is set and it's a
+// decimal number.
+func GetSequence(e event.Event) (uint64, bool) {
+	v, ok := e.Extensions()[SequenceExtension]
+	if !ok {
+		return 0, false
+	}
+	s, err := types.ToString(v)
+	if err != nil {
+		// Integer sequences, from the deprecated sequencetype Integer
+		i, err := types.ToInteger(v)
+		if err != nil || i < 0 {
+			return 0, false
+		}
+		return uint64(i), true
+	}
+	n, err := ParseSequence(s)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
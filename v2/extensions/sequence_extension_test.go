@@ -0,0 +1,177 @@
+package extensions_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+func TestSequence(t *testing.T) {
+	require.Equal(t, "00000000000000000042", extensions.FormatSequence(42))
+	require.True(t, extensions.FormatSequence(9) < extensions.FormatSequence(10))
+
+	e := event.New()
+	extensions.SetSequence(&e, 42)
+	seq, ok := extensions.GetSequence(e)
+	require.True(t, ok)
+	require.Equal(t, uint64(42), seq)
+
+	e.SetExtension(extensions.SequenceExtension, 7)
+	seq, ok = extensions.GetSequence(e)
+	require.True(t, ok)
+	require.Equal(t, uint64(7), seq)
+
+	e.SetExtension(extensions.SequenceExtension, "abc")
+	_, ok = extensions.GetSequence(e)
+	require.False(t, ok)
+}
+
+func sequenceEvent(source string, seq uint64) event.Event {
+	e := event.New()
+	e.SetSource(source)
+	e.SetType("unit.test")
+	extensions.SetSequence(&e, seq)
+	return e
+}
+
+type trackedSeq struct {
+	seq     uint64
+	status  extensions.SequenceStatus
+	missing []extensions.SequenceRange
+}
+
+func track(tracker *extensions.SequenceTracker, e event.Event) []trackedSeq {
+	return trackedSeqs(tracker.Track(e))
+}
+
+func trackedSeqs(events []extensions.TrackedEvent) []trackedSeq {
+	var out []trackedSeq
+	for _, te := range events {
+		seq, _ := extensions.GetSequence(te.Event)
+		out = append(out, trackedSeq{seq: seq, status: te.Status, missing: te.Missing})
+	}
+	return out
+}
+
+func TestSequenceTracker_Notify(t *testing.T) {
+	tracker := extensions.NewSequenceTracker()
+
+	require.Equal(t, []trackedSeq{{seq: 1, status: extensions.SequenceInOrder}}, track(tracker, sequenceEvent("a", 1)))
+	require.Equal(t, []trackedSeq{{seq: 2, status: extensions.SequenceInOrder}}, track(tracker, sequenceEvent("a", 2)))
+	require.Equal(t, []trackedSeq{{seq: 2, status: extensions.SequenceDuplicate}}, track(tracker, sequenceEvent("a", 2)))
+	require.Equal(t, []trackedSeq{{
+		seq:     6,
+		status:  extensions.SequenceGap,
+		missing: []extensions.SequenceRange{{Source: "a", First: 3, Last: 5}},
+	}}, track(tracker, sequenceEvent("a", 6)))
+	require.Equal(t, []trackedSeq{{seq: 4, status: extensions.SequenceLate}}, track(tracker, sequenceEvent("a", 4)))
+	require.Equal(t, []trackedSeq{{seq: 4, status: extensions.SequenceDuplicate}}, track(tracker, sequenceEvent("a", 4)))
+	require.Equal(t, []trackedSeq{{seq: 3, status: extensions.SequenceLate}}, track(tracker, sequenceEvent("a", 3)))
+
+	// Sources are tracked independently
+	require.Equal(t, []trackedSeq{{seq: 10, status: extensions.SequenceInOrder}}, track(tracker, sequenceEvent("b", 10)))
+	require.Equal(t, []trackedSeq{{seq: 7, status: extensions.SequenceInOrder}}, track(tracker, sequenceEvent("a", 7)))
+
+	untracked := event.New()
+	untracked.SetSource("a")
+	require.Equal(t, extensions.SequenceUntracked, tracker.Track(untracked)[0].Status)
+}
+
+func TestSequenceTracker_ReorderWindow(t *testing.T) {
+	tracker := extensions.NewSequenceTracker(extensions.WithReorderWindow(2))
+
+	require.Equal(t, []trackedSeq{{seq: 1, status: extensions.SequenceInOrder}}, track(tracker, sequenceEvent("a", 1)))
+	require.Empty(t, track(tracker, sequenceEvent("a", 3)))
+	require.Equal(t, []trackedSeq{{seq: 3, status: extensions.SequenceDuplicate}}, track(tracker, sequenceEvent("a", 3)))
+	require.Equal(t, []trackedSeq{
+		{seq: 2, status: extensions.SequenceInOrder},
+		{seq: 3, status: extensions.SequenceReordered},
+	}, track(tracker, sequenceEvent("a", 2)))
+
+	// 4 never arrives: the window overflows at the third buffered event
+	require.Empty(t, track(tracker, sequenceEvent("a", 6)))
+	require.Empty(t, track(tracker, sequenceEvent("a", 5)))
+	require.Equal(t, []trackedSeq{
+		{seq: 5, status: extensions.SequenceGap, missing: []extensions.SequenceRange{{Source: "a", First: 4, Last: 4}}},
+		{seq: 6, status: extensions.SequenceReordered},
+		{seq: 7, status: extensions.SequenceReordered},
+	}, track(tracker, sequenceEvent("a", 7)))
+	require.Equal(t, []trackedSeq{{seq: 4, status: extensions.SequenceLate}}, track(tracker, sequenceEvent("a", 4)))
+}
+
+func TestSequenceTracker_Receiver(t *testing.T) {
+	tracker := extensions.NewSequenceTracker(extensions.WithReorderWindow(10))
+	var got []uint64
+	fail := errors.New("unit test")
+	receiver := tracker.Receiver(func(ctx context.Context, te extensions.TrackedEvent) protocol.Result {
+		seq, _ := extensions.GetSequence(te.Event)
+		got = append(got, seq)
+		if seq%3 == 0 {
+			return fail
+		}
+		return nil
+	})
+
+	require.NoError(t, receiver(context.TODO(), sequenceEvent("a", 1)))
+	require.NoError(t, receiver(context.TODO(), sequenceEvent("a", 3)))
+	require.NoError(t, receiver(context.TODO(), sequenceEvent("a", 4)))
+	// 4 is delivered even if 3 fails
+	require.Equal(t, fail, receiver(context.TODO(), sequenceEvent("a", 2)))
+	require.Equal(t, []uint64{1, 2, 3, 4}, got)
+
+	require.NoError(t, receiver(context.TODO(), sequenceEvent("a", 6)))
+	require.NoError(t, receiver(context.TODO(), sequenceEvent("a", 9)))
+	require.NoError(t, receiver(context.TODO(), sequenceEvent("a", 8)))
+	require.NoError(t, receiver(context.TODO(), sequenceEvent("a", 7)))
+	got = nil
+	result := receiver(context.TODO(), sequenceEvent("a", 5))
+	require.Equal(t, []uint64{5, 6, 7, 8, 9}, got)
+	require.True(t, errors.Is(result, fail))
+	require.Contains(t, result.Error(), "and 1 more released events failed")
+}
+
+func TestSequenceTracker_MaxWait(t *testing.T) {
+	tracker := extensions.NewSequenceTracker(extensions.WithReorderWindow(10), extensions.WithMaxWait(20*time.Millisecond))
+
+	require.Equal(t, []trackedSeq{{seq: 1, status: extensions.SequenceInOrder}}, track(tracker, sequenceEvent("a", 1)))
+	require.Equal(t, []trackedSeq{{seq: 1, status: extensions.SequenceInOrder}}, track(tracker, sequenceEvent("b", 1)))
+	require.Empty(t, track(tracker, sequenceEvent("a", 3)))
+	require.Empty(t, track(tracker, sequenceEvent("a", 4)))
+	require.Empty(t, track(tracker, sequenceEvent("b", 3)))
+	require.Empty(t, tracker.Expire())
+
+	time.Sleep(30 * time.Millisecond)
+	// The next event of a source releases its expired events first
+	require.Equal(t, []trackedSeq{
+		{seq: 3, status: extensions.SequenceGap, missing: []extensions.SequenceRange{{Source: "a", First: 2, Last: 2}}},
+		{seq: 4, status: extensions.SequenceReordered},
+		{seq: 5, status: extensions.SequenceInOrder},
+	}, track(tracker, sequenceEvent("a", 5)))
+	require.Equal(t, []trackedSeq{
+		{seq: 3, status: extensions.SequenceGap, missing: []extensions.SequenceRange{{Source: "b", First: 2, Last: 2}}},
+	}, trackedSeqs(tracker.Expire()))
+	require.Equal(t, []trackedSeq{{seq: 2, status: extensions.SequenceLate}}, track(tracker, sequenceEvent("b", 2)))
+}
+
+func TestSequenceTracker_Flush(t *testing.T) {
+	tracker := extensions.NewSequenceTracker(extensions.WithReorderWindow(10))
+
+	require.Equal(t, []trackedSeq{{seq: 1, status: extensions.SequenceInOrder}}, track(tracker, sequenceEvent("a", 1)))
+	require.Empty(t, track(tracker, sequenceEvent("a", 3)))
+	require.Empty(t, track(tracker, sequenceEvent("a", 6)))
+	require.Empty(t, tracker.Expire(), "no max wait")
+
+	require.Equal(t, []trackedSeq{
+		{seq: 3, status: extensions.SequenceGap, missing: []extensions.SequenceRange{{Source: "a", First: 2, Last: 2}}},
+		{seq: 6, status: extensions.SequenceGap, missing: []extensions.SequenceRange{{Source: "a", First: 4, Last: 5}}},
+	}, trackedSeqs(tracker.Flush()))
+	require.Empty(t, tracker.Flush())
+	require.Equal(t, []trackedSeq{{seq: 7, status: extensions.SequenceInOrder}}, track(tracker, sequenceEvent("a", 7)))
+}
@@ -0,0 +1,347 @@
+package extensions
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+// SequenceStatus describes how an event relates to the sequence of its source.
+type SequenceStatus int
+
+const (
+	// SequenceInOrder is the next expected event of the source, or the first
+	// event seen from the source.
+	SequenceInOrder SequenceStatus = iota
+	// SequenceReordered is an event that arrived before some of its
+	// predecessors, buffered and released in order once they arrived.
+	SequenceReordered
+	// SequenceGap is an event after missing events of the source. The missing
+	// sequences are reported with the event.
+	SequenceGap
+	// SequenceLate is a missing event, previously reported in a gap, that
+	// eventually arrived.
+	SequenceLate
+	// SequenceDuplicate is an event whose sequence was already seen.
+	SequenceDuplicate
+	// SequenceUntracked is an event without a decimal sequence extension.
+	SequenceUntracked
+)
+
+// String returns the name of the status.
+func (s SequenceStatus) String() string {
+	switch s {
+	case SequenceInOrder:
+		return "in order"
+	case SequenceReordered:
+		return "reordered"
+	case SequenceGap:
+		return "gap"
+	case SequenceLate:
+		return "late"
+	case SequenceDuplicate:
+		return "duplicate"
+	case SequenceUntracked:
+		return "untracked"
+	}
+	return "unknown"
+}
+
+// SequenceRange is an inclusive range of sequences of a source.
+type SequenceRange struct {
+	Source string
+	First  uint64
+	Last   uint64
+}
+
+// TrackedEvent is an event with its position in the sequence of its source.
+type TrackedEvent struct {
+	Event  event.Event
+	Status SequenceStatus
+	// Missing are the sequences missing before the event, set with SequenceGap.
+	Missing []SequenceRange
+}
+
+// maxMissingRanges bounds the missing ranges remembered per source, to
+// recognize the late events.
+const maxMissingRanges = 1024
+
+type sourceSequence struct {
+	next     uint64
+	missing  []SequenceRange
+	buffered map[uint64]bufferedEvent
+}
+
+type bufferedEvent struct {
+	event event.Event
+	at    time.Time
+}
+
+// SequenceTracker tracks the sequence extension of the incoming events per
+// source, detecting gaps, duplicates and out of order arrivals.
+//
+// By default the events are released as soon as they are tracked, and the
+// gaps are reported with the first event after them. With a reorder window,
+// the events arriving ahead of their predecessors are buffered until the
+// predecessors arrive, or until more than window events are buffered for the
+// source, in which case the gap is reported. WithMaxWait bounds the time the
+// events wait in the window as well, and Flush releases all of them, e.g. when
+// the application stops: otherwise the events buffered after the last missing
+// one of a source are released only when more events of the source arrive.
+//
+// The tracker is safe for concurrent use, but the events are released in order
+// only to a single caller at a time: use client.WithPollGoroutines(1) when
+// the order matters.
+type SequenceTracker struct {
+	mu      sync.Mutex
+	window  int
+	maxWait time.Duration
+	sources map[string]*sourceSequence
+}
+
+// SequenceTrackerOption is the function signature for SequenceTracker options.
+type SequenceTrackerOption func(*SequenceTracker)
+
+// WithReorderWindow sets the number of events buffered per source while
+// waiting for the missing ones.
+func WithReorderWindow(window int) SequenceTrackerOption {
+	return func(t *SequenceTracker) {
+		t.window = window
+	}
+}
+
+// WithMaxWait sets the maximum time an event waits in the reorder window for
+// its missing predecessors. The expired events are released, reporting the
+// gap, by the next Track of their source, or by Expire.
+func WithMaxWait(maxWait time.Duration) SequenceTrackerOption {
+	return func(t *SequenceTracker) {
+		t.maxWait = maxWait
+	}
+}
+
+// NewSequenceTracker returns a new SequenceTracker.
+func NewSequenceTracker(opts ...SequenceTrackerOption) *SequenceTracker {
+	t := &SequenceTracker{sources: make(map[string]*sourceSequence)}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Track records e and returns the events to hand to the application, in
+// sequence order. Without reorder window it returns exactly e. With a reorder
+// window it returns no event when e is buffered, or e followed by the
+// buffered events it unblocked.
+func (t *SequenceTracker) Track(e event.Event) []TrackedEvent {
+	seq, ok := GetSequence(e)
+	if !ok {
+		return []TrackedEvent{{Event: e, Status: SequenceUntracked}}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.sources[e.Source()]
+	if !ok {
+		t.sources[e.Source()] = &sourceSequence{next: seq + 1}
+		return []TrackedEvent{{Event: e, Status: SequenceInOrder}}
+	}
+
+	now := time.Now()
+	expired := t.expire(e.Source(), s, now)
+
+	switch {
+	case seq == s.next:
+		s.next++
+		return append(append(expired, TrackedEvent{Event: e, Status: SequenceInOrder}), s.release(SequenceReordered)...)
+
+	case seq < s.next:
+		if s.fill(seq) {
+			return append(expired, TrackedEvent{Event: e, Status: SequenceLate})
+		}
+		return append(expired, TrackedEvent{Event: e, Status: SequenceDuplicate})
+
+	case t.window <= 0:
+		missing := s.skip(e.Source(), seq)
+		s.next = seq + 1
+		return []TrackedEvent{{Event: e, Status: SequenceGap, Missing: []SequenceRange{missing}}}
+	}
+
+	if _, dup := s.buffered[seq]; dup {
+		return append(expired, TrackedEvent{Event: e, Status: SequenceDuplicate})
+	}
+	if s.buffered == nil {
+		s.buffered = make(map[uint64]bufferedEvent)
+	}
+	s.buffered[seq] = bufferedEvent{event: e, at: now}
+	if len(s.buffered) <= t.window {
+		return expired
+	}
+
+	// The window is full: give up waiting for the missing events
+	return append(expired, s.giveUp(e.Source())...)
+}
+
+// Expire releases the events that waited in the reorder window longer than
+// the WithMaxWait duration, reporting the gaps before them. Call it
+// periodically, e.g. with a time.Ticker, to release the events of the sources
+// that stopped sending. Like Track, it releases the events in order only to a
+// single caller at a time.
+func (t *SequenceTracker) Expire() []TrackedEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	var out []TrackedEvent
+	for _, source := range t.sortedSources() {
+		out = append(out, t.expire(source, t.sources[source], now)...)
+	}
+	return out
+}
+
+// Flush releases all the events buffered in the reorder window, reporting
+// the gaps before them, e.g. when the application stops.
+func (t *SequenceTracker) Flush() []TrackedEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var out []TrackedEvent
+	for _, source := range t.sortedSources() {
+		s := t.sources[source]
+		for len(s.buffered) > 0 {
+			out = append(out, s.giveUp(source)...)
+		}
+	}
+	return out
+}
+
+func (t *SequenceTracker) sortedSources() []string {
+	sources := make([]string, 0, len(t.sources))
+	for source := range t.sources {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// expire gives up waiting for the missing events before the events of s
+// buffered before now minus the maximum wait.
+func (t *SequenceTracker) expire(source string, s *sourceSequence, now time.Time) []TrackedEvent {
+	if t.maxWait <= 0 {
+		return nil
+	}
+	var out []TrackedEvent
+	for len(s.buffered) > 0 && now.Sub(s.oldestBuffered()) > t.maxWait {
+		out = append(out, s.giveUp(source)...)
+	}
+	return out
+}
+
+// giveUp releases the lowest buffered event, reporting the missing events
+// before it as a gap, followed by the buffered events contiguous to it.
+func (s *sourceSequence) giveUp(source string) []TrackedEvent {
+	first := s.lowestBuffered()
+	missing := s.skip(source, first)
+	s.next = first + 1
+	gap := TrackedEvent{Event: s.buffered[first].event, Status: SequenceGap, Missing: []SequenceRange{missing}}
+	delete(s.buffered, first)
+	return append([]TrackedEvent{gap}, s.release(SequenceReordered)...)
+}
+
+// release pops the buffered events contiguous to next.
+func (s *sourceSequence) release(status SequenceStatus) []TrackedEvent {
+	var out []TrackedEvent
+	for {
+		b, ok := s.buffered[s.next]
+		if !ok {
+			return out
+		}
+		delete(s.buffered, s.next)
+		out = append(out, TrackedEvent{Event: b.event, Status: status})
+		s.next++
+	}
+}
+
+func (s *sourceSequence) oldestBuffered() time.Time {
+	var oldest time.Time
+	for _, b := range s.buffered {
+		if oldest.IsZero() || b.at.Before(oldest) {
+			oldest = b.at
+		}
+	}
+	return oldest
+}
+
+func (s *sourceSequence) lowestBuffered() uint64 {
+	keys := make([]uint64, 0, len(s.buffered))
+	for k := range s.buffered {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys[0]
+}
+
+// skip records the sequences from next to seq excluded as missing.
+func (s *sourceSequence) skip(source string, seq uint64) SequenceRange {
+	r := SequenceRange{Source: source, First: s.next, Last: seq - 1}
+	s.missing = append(s.missing, r)
+	if len(s.missing) > maxMissingRanges {
+		s.missing = s.missing[len(s.missing)-maxMissingRanges:]
+	}
+	return r
+}
+
+// fill removes seq from the missing ranges, returning false if it was not missing.
+func (s *sourceSequence) fill(seq uint64) bool {
+	for i, r := range s.missing {
+		if seq < r.First || seq > r.Last {
+			continue
+		}
+		var split []SequenceRange
+		if seq > r.First {
+			split = append(split, SequenceRange{Source: r.Source, First: r.First, Last: seq - 1})
+		}
+		if seq < r.Last {
+			split = append(split, SequenceRange{Source: r.Source, First: seq + 1, Last: r.Last})
+		}
+		s.missing = append(s.missing[:i], append(split, s.missing[i+1:]...)...)
+		return true
+	}
+	return false
+}
+
+// SequenceReceiverFn is the function signature of the receivers wrapped by
+// SequenceTracker.Receiver.
+type SequenceReceiverFn func(ctx context.Context, e TrackedEvent) protocol.Result
+
+// Receiver returns a receiver function, usable with client.StartReceiver,
+// tracking the incoming events and handing them to fn with their sequence
+// status, in order.
+// Buffered events are acknowledged when buffered: a failure of fn on an event
+// released later is not reported to its sender.
+// All the released events are handed to fn, even when it fails on one of them:
+// the returned result is the first failure, counting the other ones.
+// With WithMaxWait, the events expired in the other sources are handed to fn
+// as well.
+func (t *SequenceTracker) Receiver(fn SequenceReceiverFn) func(context.Context, event.Event) protocol.Result {
+	return func(ctx context.Context, e event.Event) protocol.Result {
+		var failure protocol.Result
+		failures := 0
+		for _, te := range append(t.Track(e), t.Expire()...) {
+			if result := fn(ctx, te); !protocol.IsACK(result) {
+				if failure == nil {
+					failure = result
+				}
+				failures++
+			}
+		}
+		if failures > 1 {
+			return protocol.NewResult("%w (and %d more released events failed)", failure, failures-1)
+		}
+		return failure
+	}
+}
@@ -0,0 +1,125 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"sort"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/extensions/internal/payload"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+// ErrDataUnavailable is returned by the transformers when the data of the
+// message is not available to them, see payload.ErrDataUnavailable.
+var ErrDataUnavailable = payload.ErrDataUnavailable
+
+// dataDigestName is the name of the data digest in the canonical representation.
+// It's not a valid attribute name, so it cannot clash with extensions.
+const dataDigestName = "#data"
+
+var signedAttributes = []spec.Kind{
+	spec.ID,
+	spec.Source,
+	spec.SpecVersion,
+	spec.Type,
+	spec.DataContentType,
+	spec.DataSchema,
+	spec.Subject,
+	spec.Time,
+}
+
+// canonicalize returns the canonical representation of the event read by
+// reader: a JSON array of [name, value] pairs sorted by name, including the
+// context attributes, the selected extensions and the digest of the data.
+// Values are formatted with their canonical string representation, so the
+// result is the same regardless of the encoding of the message.
+func canonicalize(reader binding.MessageMetadataReader, extensions []string) ([]byte, error) {
+	em, ok := reader.(*binding.EventMessage)
+	if !ok {
+		return nil, ErrDataUnavailable
+	}
+
+	pairs := make([][2]string, 0, len(signedAttributes)+len(extensions)+1)
+	for _, kind := range signedAttributes {
+		_, v := reader.GetAttribute(kind)
+		if v == nil {
+			continue
+		}
+		s, err := formatAttribute(kind, v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot format attribute %s: %w", kind, err)
+		}
+		pairs = append(pairs, [2]string{kind.String(), s})
+	}
+
+	for _, name := range extensions {
+		v := getExtension(reader, name)
+		if v == nil {
+			continue
+		}
+		s, err := types.Format(v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot format extension %s: %w", name, err)
+		}
+		pairs = append(pairs, [2]string{name, s})
+	}
+
+	pairs = append(pairs, [2]string{dataDigestName, dataDigest(em)})
+
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+	return json.Marshal(pairs)
+}
+
+// getExtension returns the extension value, or nil if the extension is not
+// set. Event messages report missing extensions as empty strings.
+func getExtension(reader binding.MessageMetadataReader, name string) interface{} {
+	v := reader.GetExtension(name)
+	if s, ok := v.(string); ok && s == "" {
+		return nil
+	}
+	return v
+}
+
+func formatAttribute(kind spec.Kind, v interface{}) (string, error) {
+	if kind == spec.Time {
+		t, err := types.ToTime(v)
+		if err != nil {
+			return "", err
+		}
+		return types.FormatTime(t), nil
+	}
+	return types.Format(v)
+}
+
+// dataDigest returns the base64url encoded SHA-256 digest of the event data.
+// JSON data is compacted first, because the structured encoding doesn't
+// preserve the insignificant whitespaces.
+func dataDigest(em *binding.EventMessage) string {
+	data := em.DataEncoded
+	if len(data) > 0 && isJSON(em.Context.GetDataContentType()) {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, data); err == nil {
+			data = buf.Bytes()
+		}
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || mediaType == "text/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,17 @@
+/*
+Package signature implements the signing and the verification of events, to let the receivers prove that an event
+really comes from the expected producer and was not tampered with.
+
+The signature covers a canonical representation of the event: the context attributes, the extensions selected when
+creating the Signer and a SHA-256 digest of the data. The canonical representation doesn't depend on the encoding,
+so an event signed before being sent in binary mode can be verified after being received in structured mode and
+vice versa.
+
+The signature is a JWS (RFC 7515) with detached payload, stored in the "signature" extension. HMAC (HS256), Ed25519
+(EdDSA) and ECDSA (ES256, ES384, ES512) keys are supported.
+
+Events can be signed with Signer.SignEvent or with the Signer.Transformer on the send path. Incoming events can be
+verified with Verifier.VerifyEvent, with the Verifier.Transformer while converting messages to events, or in the
+client using client.WithInboundEventValidator(verifier.ValidateEvent), which NACKs tampered and unsigned events.
+*/
+package signature
@@ -0,0 +1,180 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// JWS algorithms supported by Signer and Verifier.
+const (
+	HS256 = "HS256"
+	EdDSA = "EdDSA"
+	ES256 = "ES256"
+	ES384 = "ES384"
+	ES512 = "ES512"
+)
+
+var (
+	// ErrInvalidSignature is returned when the signature doesn't match the event.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrUnsigned is returned when verifying an event without signature.
+	ErrUnsigned = errors.New("event is not signed")
+)
+
+// header is the JWS protected header. Ext lists the extensions covered by the
+// signature, so the verifier rebuilds the same canonical representation.
+type header struct {
+	Alg string   `json:"alg"`
+	Kid string   `json:"kid,omitempty"`
+	Ext []string `json:"ext,omitempty"`
+}
+
+// encode returns the detached JWS compact serialization of payload.
+func encode(h header, payload []byte, sign func([]byte) ([]byte, error)) (string, error) {
+	hb, err := json.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+	protected := base64.RawURLEncoding.EncodeToString(hb)
+	sig, err := sign(signingInput(protected, payload))
+	if err != nil {
+		return "", err
+	}
+	return protected + ".." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// decode parses a detached JWS compact serialization.
+func decode(jws string) (h header, protected string, sig []byte, err error) {
+	parts := strings.Split(jws, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return h, "", nil, fmt.Errorf("%w: malformed detached JWS", ErrInvalidSignature)
+	}
+	hb, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return h, "", nil, fmt.Errorf("%w: malformed header: %v", ErrInvalidSignature, err)
+	}
+	if err := json.Unmarshal(hb, &h); err != nil {
+		return h, "", nil, fmt.Errorf("%w: malformed header: %v", ErrInvalidSignature, err)
+	}
+	sig, err = base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return h, "", nil, fmt.Errorf("%w: malformed signature: %v", ErrInvalidSignature, err)
+	}
+	return h, parts[0], sig, nil
+}
+
+func signingInput(protected string, payload []byte) []byte {
+	return []byte(protected + "." + base64.RawURLEncoding.EncodeToString(payload))
+}
+
+func hmacSign(secret []byte) func([]byte) ([]byte, error) {
+	return func(input []byte) ([]byte, error) {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(input)
+		return mac.Sum(nil), nil
+	}
+}
+
+func ed25519Sign(key ed25519.PrivateKey) func([]byte) ([]byte, error) {
+	return func(input []byte) ([]byte, error) {
+		return ed25519.Sign(key, input), nil
+	}
+}
+
+func ecdsaAlgorithm(curve elliptic.Curve) (string, crypto.Hash, error) {
+	switch curve {
+	case elliptic.P256():
+		return ES256, crypto.SHA256, nil
+	case elliptic.P384():
+		return ES384, crypto.SHA384, nil
+	case elliptic.P521():
+		return ES512, crypto.SHA512, nil
+	}
+	return "", 0, fmt.Errorf("unsupported ECDSA curve %s", curve.Params().Name)
+}
+
+func hashSum(h crypto.Hash, input []byte) []byte {
+	switch h {
+	case crypto.SHA384:
+		sum := sha512.Sum384(input)
+		return sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512(input)
+		return sum[:]
+	default:
+		sum := sha256.Sum256(input)
+		return sum[:]
+	}
+}
+
+// ecdsaSign signs with the JWS encoding of the ECDSA signature: the fixed
+// size big-endian R and S concatenated.
+func ecdsaSign(key *ecdsa.PrivateKey, h crypto.Hash) func([]byte) ([]byte, error) {
+	size := (key.Curve.Params().BitSize + 7) / 8
+	return func(input []byte) ([]byte, error) {
+		r, s, err := ecdsa.Sign(rand.Reader, key, hashSum(h, input))
+		if err != nil {
+			return nil, err
+		}
+		sig := make([]byte, 2*size)
+		rb, sb := r.Bytes(), s.Bytes()
+		copy(sig[size-len(rb):size], rb)
+		copy(sig[2*size-len(sb):], sb)
+		return sig, nil
+	}
+}
+
+// verify checks sig against input with key, which must match the algorithm
+// declared in the header to prevent algorithm substitution.
+func verify(alg string, key interface{}, input, sig []byte) error {
+	switch k := key.(type) {
+	case []byte:
+		if alg != HS256 {
+			return fmt.Errorf("%w: algorithm %q doesn't match an HMAC key", ErrInvalidSignature, alg)
+		}
+		expected, _ := hmacSign(k)(input)
+		if !hmac.Equal(expected, sig) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case ed25519.PublicKey:
+		if alg != EdDSA {
+			return fmt.Errorf("%w: algorithm %q doesn't match an Ed25519 key", ErrInvalidSignature, alg)
+		}
+		if !ed25519.Verify(k, input, sig) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		keyAlg, h, err := ecdsaAlgorithm(k.Curve)
+		if err != nil {
+			return err
+		}
+		if alg != keyAlg {
+			return fmt.Errorf("%w: algorithm %q doesn't match an %s key", ErrInvalidSignature, alg, keyAlg)
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, hashSum(h, input), r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported verification key type %T", key)
+}
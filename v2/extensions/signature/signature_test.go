@@ -0,0 +1,194 @@
+package signature
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+)
+
+type testKeys struct {
+	name     string
+	signer   *Signer
+	verifier *Verifier
+}
+
+func newTestKeys(t *testing.T, extensions ...string) []testKeys {
+	secret := []byte("a very secret secret")
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecSigner, err := NewECDSASigner("ec", ecPriv, extensions...)
+	require.NoError(t, err)
+
+	ec384Priv, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	ec384Signer, err := NewECDSASigner("ec384", ec384Priv, extensions...)
+	require.NoError(t, err)
+
+	keys := StaticKeys(map[string]interface{}{
+		"hmac":  secret,
+		"ed":    edPub,
+		"ec":    &ecPriv.PublicKey,
+		"ec384": &ec384Priv.PublicKey,
+	})
+	return []testKeys{
+		{name: "HS256", signer: NewHMACSigner("hmac", secret, extensions...), verifier: NewVerifier(keys)},
+		{name: "EdDSA", signer: NewEd25519Signer("ed", edPriv, extensions...), verifier: NewVerifier(keys)},
+		{name: "ES256", signer: ecSigner, verifier: NewVerifier(keys)},
+		{name: "ES384", signer: ec384Signer, verifier: NewVerifier(keys)},
+	}
+}
+
+func newTestEvent(t *testing.T) event.Event {
+	e := event.New()
+	e.SetID("1")
+	e.SetType("com.example.order.created")
+	e.SetSource("/orders")
+	e.SetSubject("order-1")
+	e.SetTime(time.Date(2020, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60)))
+	e.SetExtension("tenant", "acme")
+	e.SetExtension("priority", 3)
+	require.NoError(t, e.SetData(event.ApplicationJSON, map[string]string{"hello": "world"}))
+	return e
+}
+
+func TestSignAndVerifyEvent(t *testing.T) {
+	for _, k := range newTestKeys(t, "tenant") {
+		t.Run(k.name, func(t *testing.T) {
+			e := newTestEvent(t)
+			require.NoError(t, k.signer.SignEvent(&e))
+			require.NotNil(t, e.Extensions()[SignatureExtension])
+			require.NoError(t, k.verifier.VerifyEvent(e))
+		})
+	}
+}
+
+func TestVerifyEvent_Tampered(t *testing.T) {
+	testCases := map[string]func(e *event.Event){
+		"data": func(e *event.Event) {
+			_ = e.SetData(event.ApplicationJSON, map[string]string{"hello": "mars"})
+		},
+		"type":   func(e *event.Event) { e.SetType("com.example.order.deleted") },
+		"source": func(e *event.Event) { e.SetSource("/other") },
+		"time":   func(e *event.Event) { e.SetTime(e.Time().Add(time.Second)) },
+		"subject removed": func(e *event.Event) {
+			e.SetSubject("")
+		},
+		"signed extension": func(e *event.Event) { e.SetExtension("tenant", "evil") },
+		"signature":        func(e *event.Event) { e.SetExtension(SignatureExtension, "e30..AAAA") },
+	}
+	for _, k := range newTestKeys(t, "tenant") {
+		for n, tamper := range testCases {
+			t.Run(k.name+"/"+n, func(t *testing.T) {
+				e := newTestEvent(t)
+				require.NoError(t, k.signer.SignEvent(&e))
+				tamper(&e)
+				err := k.verifier.VerifyEvent(e)
+				require.True(t, errors.Is(err, ErrInvalidSignature), "unexpected error %v", err)
+			})
+		}
+	}
+}
+
+func TestVerifyEvent_UnsignedExtensionChanged(t *testing.T) {
+	for _, k := range newTestKeys(t, "tenant") {
+		t.Run(k.name, func(t *testing.T) {
+			e := newTestEvent(t)
+			require.NoError(t, k.signer.SignEvent(&e))
+			e.SetExtension("priority", 1)
+			require.NoError(t, k.verifier.VerifyEvent(e))
+		})
+	}
+}
+
+func TestVerifyEvent_Unsigned(t *testing.T) {
+	v := NewVerifier(StaticKeys(nil))
+	err := v.VerifyEvent(newTestEvent(t))
+	require.True(t, errors.Is(err, ErrUnsigned))
+
+	err = v.ValidateEvent(context.Background(), newTestEvent(t))
+	var validationErr event.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.True(t, errors.Is(validationErr[SignatureExtension], ErrUnsigned))
+}
+
+func TestVerifyEvent_AlgorithmMismatch(t *testing.T) {
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// Signed with HMAC, using the public key as secret
+	e := newTestEvent(t)
+	require.NoError(t, NewHMACSigner("ed", edPub).SignEvent(&e))
+
+	v := NewVerifier(StaticKeys(map[string]interface{}{"ed": edPub}))
+	require.True(t, errors.Is(v.VerifyEvent(e), ErrInvalidSignature))
+}
+
+func TestVerifyEvent_RequiredExtensions(t *testing.T) {
+	secret := []byte("secret")
+	keys := StaticKeys(map[string]interface{}{"hmac": secret})
+
+	e := newTestEvent(t)
+	require.NoError(t, NewHMACSigner("hmac", secret).SignEvent(&e))
+
+	require.NoError(t, NewVerifier(keys).VerifyEvent(e))
+	require.True(t, errors.Is(NewVerifier(keys, WithRequiredExtensions("tenant")).VerifyEvent(e), ErrInvalidSignature))
+}
+
+func TestTransformers_AcrossEncodings(t *testing.T) {
+	encodings := map[string]func(ctx context.Context) context.Context{
+		"binary":     binding.WithForceBinary,
+		"structured": binding.WithForceStructured,
+	}
+	for _, k := range newTestKeys(t, "tenant", "priority") {
+		for n, encode := range encodings {
+			t.Run(k.name+"/"+n, func(t *testing.T) {
+				ctx := encode(context.Background())
+				e := newTestEvent(t)
+				e.DataEncoded = []byte("{\n  \"hello\": \"world\"\n}")
+
+				req, err := nethttp.NewRequest(nethttp.MethodPost, "http://localhost", nil)
+				require.NoError(t, err)
+				require.NoError(t, http.WriteRequest(ctx, binding.ToMessage(&e), req, k.signer.Transformer()))
+
+				got, err := binding.ToEvent(ctx, http.NewMessageFromHttpRequest(req), k.verifier.Transformer())
+				require.NoError(t, err)
+				require.NoError(t, k.verifier.VerifyEvent(*got))
+
+				got.SetExtension("tenant", "evil")
+				require.True(t, errors.Is(k.verifier.VerifyEvent(*got), ErrInvalidSignature))
+			})
+		}
+	}
+}
+
+func TestTransformer_DataUnavailable(t *testing.T) {
+	secret := []byte("secret")
+	e := newTestEvent(t)
+	require.NoError(t, NewHMACSigner("hmac", secret).SignEvent(&e))
+
+	req, err := nethttp.NewRequest(nethttp.MethodPost, "http://localhost", nil)
+	require.NoError(t, err)
+	require.NoError(t, http.WriteRequest(binding.WithForceBinary(context.Background()), binding.ToMessage(&e), req))
+
+	out, err := nethttp.NewRequest(nethttp.MethodPost, "http://localhost", nil)
+	require.NoError(t, err)
+	v := NewVerifier(StaticKeys(map[string]interface{}{"hmac": secret}))
+	err = http.WriteRequest(context.Background(), http.NewMessageFromHttpRequest(req), out, v.Transformer())
+	require.True(t, errors.Is(err, ErrDataUnavailable))
+}
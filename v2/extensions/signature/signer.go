@@ -0,0 +1,95 @@
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"sort"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+// SignatureExtension is the extension holding the detached JWS signing the event.
+const SignatureExtension = "signature"
+
+// Signer signs events with a key.
+type Signer struct {
+	alg        string
+	keyID      string
+	sign       func([]byte) ([]byte, error)
+	extensions []string
+}
+
+// NewHMACSigner returns a Signer using HMAC with SHA-256 (HS256) and the
+// shared secret identified by keyID.
+// extensions are the names of the extensions covered by the signature, in
+// addition to the context attributes and the data.
+func NewHMACSigner(keyID string, secret []byte, extensions ...string) *Signer {
+	return newSigner(HS256, keyID, hmacSign(secret), extensions)
+}
+
+// NewEd25519Signer returns a Signer using Ed25519 (EdDSA) and the private key
+// identified by keyID.
+// extensions are the names of the extensions covered by the signature, in
+// addition to the context attributes and the data.
+func NewEd25519Signer(keyID string, key ed25519.PrivateKey, extensions ...string) *Signer {
+	return newSigner(EdDSA, keyID, ed25519Sign(key), extensions)
+}
+
+// NewECDSASigner returns a Signer using ECDSA and the private key identified
+// by keyID. The algorithm (ES256, ES384 or ES512) is chosen from the curve of
+// the key.
+// extensions are the names of the extensions covered by the signature, in
+// addition to the context attributes and the data.
+func NewECDSASigner(keyID string, key *ecdsa.PrivateKey, extensions ...string) (*Signer, error) {
+	alg, h, err := ecdsaAlgorithm(key.Curve)
+	if err != nil {
+		return nil, err
+	}
+	return newSigner(alg, keyID, ecdsaSign(key, h), extensions), nil
+}
+
+func newSigner(alg, keyID string, sign func([]byte) ([]byte, error), extensions []string) *Signer {
+	ext := make([]string, 0, len(extensions))
+	for _, name := range extensions {
+		if name != SignatureExtension {
+			ext = append(ext, name)
+		}
+	}
+	sort.Strings(ext)
+	return &Signer{alg: alg, keyID: keyID, sign: sign, extensions: ext}
+}
+
+// SignEvent signs e, setting the signature extension.
+// Any change to the signed attributes or to the data after signing
+// invalidates the signature.
+func (s *Signer) SignEvent(e *event.Event) error {
+	sig, err := s.signature((*binding.EventMessage)(e))
+	if err != nil {
+		return err
+	}
+	e.SetExtension(SignatureExtension, sig)
+	return nil
+}
+
+// Transformer returns a binding.Transformer signing the event while it's
+// written, e.g. by a protocol Sender.
+// It fails with ErrDataUnavailable when the message is directly written
+// without being converted to an event.
+func (s *Signer) Transformer() binding.Transformer {
+	return binding.TransformerFunc(func(reader binding.MessageMetadataReader, writer binding.MessageMetadataWriter) error {
+		sig, err := s.signature(reader)
+		if err != nil {
+			return err
+		}
+		return writer.SetExtension(SignatureExtension, sig)
+	})
+}
+
+func (s *Signer) signature(reader binding.MessageMetadataReader) (string, error) {
+	payload, err := canonicalize(reader, s.extensions)
+	if err != nil {
+		return "", err
+	}
+	return encode(header{Alg: s.alg, Kid: s.keyID, Ext: s.extensions}, payload, s.sign)
+}
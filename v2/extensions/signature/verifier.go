@@ -0,0 +1,123 @@
+package signature
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+// KeyResolver returns the verification key identified by keyID: a []byte
+// secret for HS256, an ed25519.PublicKey for EdDSA or an *ecdsa.PublicKey for
+// ES256, ES384 and ES512.
+type KeyResolver func(keyID string) (interface{}, error)
+
+// StaticKeys returns a KeyResolver looking up the keys by id in keys.
+func StaticKeys(keys map[string]interface{}) KeyResolver {
+	return func(keyID string) (interface{}, error) {
+		if k, ok := keys[keyID]; ok {
+			return k, nil
+		}
+		return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidSignature, keyID)
+	}
+}
+
+// Verifier verifies the signature of events.
+type Verifier struct {
+	resolve            KeyResolver
+	requiredExtensions []string
+}
+
+// VerifierOption is the function signature for Verifier options.
+type VerifierOption func(*Verifier)
+
+// WithRequiredExtensions makes the Verifier reject the events whose signature
+// doesn't cover the provided extensions.
+func WithRequiredExtensions(extensions ...string) VerifierOption {
+	return func(v *Verifier) {
+		v.requiredExtensions = append(v.requiredExtensions, extensions...)
+	}
+}
+
+// NewVerifier returns a Verifier resolving the verification keys with keys.
+func NewVerifier(keys KeyResolver, opts ...VerifierOption) *Verifier {
+	v := &Verifier{resolve: keys}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// VerifyEvent verifies the signature of e. It returns ErrUnsigned if e is not
+// signed, or an error wrapping ErrInvalidSignature if the signature doesn't
+// match.
+func (v *Verifier) VerifyEvent(e event.Event) error {
+	return v.verify((*binding.EventMessage)(&e))
+}
+
+// ValidateEvent verifies the signature of e, returning an
+// event.ValidationError on failure. The signature matches
+// client.EventValidator, so the Verifier can be plugged in the client using
+// client.WithInboundEventValidator(v.ValidateEvent) to NACK the tampered and
+// unsigned events.
+func (v *Verifier) ValidateEvent(_ context.Context, e event.Event) error {
+	if err := v.VerifyEvent(e); err != nil {
+		return event.ValidationError{SignatureExtension: err}
+	}
+	return nil
+}
+
+// Transformer returns a binding.Transformer verifying the signature of the
+// message while it's converted to an event, e.g. with binding.ToEvent.
+// It fails with ErrDataUnavailable when the message is directly written
+// without being converted to an event.
+func (v *Verifier) Transformer() binding.Transformer {
+	return binding.TransformerFunc(func(reader binding.MessageMetadataReader, _ binding.MessageMetadataWriter) error {
+		return v.verify(reader)
+	})
+}
+
+func (v *Verifier) verify(reader binding.MessageMetadataReader) error {
+	raw := getExtension(reader, SignatureExtension)
+	if raw == nil {
+		return ErrUnsigned
+	}
+	jws, err := types.ToString(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	h, protected, sig, err := decode(jws)
+	if err != nil {
+		return err
+	}
+	for _, required := range v.requiredExtensions {
+		if !contains(h.Ext, required) {
+			return fmt.Errorf("%w: extension %q is not signed", ErrInvalidSignature, required)
+		}
+	}
+	if contains(h.Ext, SignatureExtension) {
+		return fmt.Errorf("%w: the signature cannot sign itself", ErrInvalidSignature)
+	}
+
+	key, err := v.resolve(h.Kid)
+	if err != nil {
+		return err
+	}
+	payload, err := canonicalize(reader, h.Ext)
+	if err != nil {
+		return err
+	}
+	return verify(h.Alg, key, signingInput(protected, payload), sig)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
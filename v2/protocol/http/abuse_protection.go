@@ -3,7 +3,6 @@ package http
 import (
 	"context"
 	cecontext "github.com/cloudevents/sdk-go/v2/context"
-	"go.uber.org/zap"
 	"net/http"
 	"strconv"
 	"strings"
@@ -66,7 +65,7 @@ func (p *Protocol) OptionsHandler(rw http.ResponseWriter, req *http.Request) {
 			go func() {
 				reqAck, err := http.NewRequest(http.MethodPost, cb, nil)
 				if err != nil {
-					cecontext.LoggerFrom(req.Context()).Errorw("OPTIONS handler failed to create http request attempting to ack callback.", zap.Error(err), zap.String("callback", cb))
+					cecontext.StructuredLoggerFrom(req.Context()).Errorw("OPTIONS handler failed to create http request attempting to ack callback.", "error", err, "callback", cb)
 					return
 				}
 
@@ -77,13 +76,13 @@ func (p *Protocol) OptionsHandler(rw http.ResponseWriter, req *http.Request) {
 
 				_, err = http.DefaultClient.Do(reqAck)
 				if err != nil {
-					cecontext.LoggerFrom(req.Context()).Errorw("OPTIONS handler failed to ack callback.", zap.Error(err), zap.String("callback", cb))
+					cecontext.StructuredLoggerFrom(req.Context()).Errorw("OPTIONS handler failed to ack callback.", "error", err, "callback", cb)
 					return
 				}
 			}()
 			return
 		} else {
-			cecontext.LoggerFrom(req.Context()).Infof("ACTION REQUIRED: Please validate web hook request callback: %q", cb)
+			cecontext.StructuredLoggerFrom(req.Context()).Infow("ACTION REQUIRED: Please validate web hook request callback.", "callback", cb)
 			// TODO: what to do pending https://github.com/cloudevents/spec/issues/617
 			return
 		}
@@ -96,15 +95,15 @@ func (p *Protocol) OptionsHandler(rw http.ResponseWriter, req *http.Request) {
 }
 
 func (p *Protocol) ValidateRequestOrigin(req *http.Request) (string, bool) {
-	return p.validateOrigin(req.Header.Get("WebHook-Request-Origin"))
+	return p.validateOrigin(req.Context(), req.Header.Get("WebHook-Request-Origin"))
 }
 
 func (p *Protocol) ValidateOrigin(req *http.Request) (string, bool) {
-	return p.validateOrigin(req.Header.Get("Origin"))
+	return p.validateOrigin(req.Context(), req.Header.Get("Origin"))
 }
 
-func (p *Protocol) validateOrigin(ro string) (string, bool) {
-	cecontext.LoggerFrom(context.TODO()).Infow("Validating origin.", zap.String("origin", ro))
+func (p *Protocol) validateOrigin(ctx context.Context, ro string) (string, bool) {
+	cecontext.StructuredLoggerFrom(ctx).Debugw("Validating origin.", "origin", ro)
 
 	for _, ao := range p.WebhookConfig.AllowedOrigins {
 		if ao == "*" {
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"context"
+	nethttp "net/http"
+	"net/url"
+)
+
+// ProtocolContext holds the metadata of the HTTP request of a received message.
+type ProtocolContext struct {
+	Method     string
+	URL        *url.URL
+	Header     nethttp.Header
+	Host       string
+	RemoteAddr string
+}
+
+// NewProtocolContext creates a new ProtocolContext from an http.Request.
+func NewProtocolContext(req *nethttp.Request) ProtocolContext {
+	if req == nil {
+		return ProtocolContext{}
+	}
+	return ProtocolContext{
+		Method:     req.Method,
+		URL:        req.URL,
+		Header:     req.Header,
+		Host:       req.Host,
+		RemoteAddr: req.RemoteAddr,
+	}
+}
+
+// Opaque key type used to store ProtocolContext
+type protocolContextKeyType struct{}
+
+var protocolContextKey = protocolContextKeyType{}
+
+// WithProtocolContext returns a context with the given ProtocolContext into the provided context object.
+func WithProtocolContext(ctx context.Context, pctx ProtocolContext) context.Context {
+	return context.WithValue(ctx, protocolContextKey, pctx)
+}
+
+// ProtocolContextFrom pulls a ProtocolContext out of a context, or returns a
+// zero ProtocolContext.
+func ProtocolContextFrom(ctx context.Context) ProtocolContext {
+	if pctx, ok := ctx.Value(protocolContextKey).(ProtocolContext); ok {
+		return pctx
+	}
+	return ProtocolContext{}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/format"
 	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 )
 
 const prefix = "Ce-"
@@ -37,13 +38,16 @@ type Message struct {
 	BodyReader io.ReadCloser
 	OnFinish   func(error) error
 
-	format  format.Format
-	version spec.Version
+	format          format.Format
+	version         spec.Version
+	protocolContext *ProtocolContext
 }
 
 // Check if http.Message implements binding.Message
 var _ binding.Message = (*Message)(nil)
 var _ binding.MessageMetadataReader = (*Message)(nil)
+var _ extensions.TraceContextReader = (*Message)(nil)
+var _ binding.ProtocolContextMessage = (*Message)(nil)
 
 // NewMessage returns a binding.Message with header and data.
 // The returned binding.Message *cannot* be read several times. In order to read it more times, buffer it using binding/buffering methods
@@ -64,7 +68,10 @@ func NewMessageFromHttpRequest(req *nethttp.Request) *Message {
 	if req == nil {
 		return nil
 	}
-	return NewMessage(req.Header, req.Body)
+	m := NewMessage(req.Header, req.Body)
+	pctx := NewProtocolContext(req)
+	m.protocolContext = &pctx
+	return m
 }
 
 // NewMessageFromHttpResponse returns a binding.Message with header and data.
@@ -147,6 +154,25 @@ func (m *Message) GetExtension(name string) interface{} {
 	return nil
 }
 
+// ReadTraceContext implements extensions.TraceContextReader, reading the W3C
+// trace context headers of the request or response.
+func (m *Message) ReadTraceContext() (extensions.DistributedTracingExtension, bool) {
+	tp := m.Header.Get(extensions.TraceParentHeader)
+	if tp == "" {
+		return extensions.DistributedTracingExtension{}, false
+	}
+	return extensions.DistributedTracingExtension{TraceParent: tp, TraceState: m.Header.Get(extensions.TraceStateHeader)}, true
+}
+
+// WithProtocolContext implements binding.ProtocolContextMessage, attaching the
+// ProtocolContext of the request the message was read from.
+func (m *Message) WithProtocolContext(ctx context.Context) context.Context {
+	if m.protocolContext == nil {
+		return ctx
+	}
+	return WithProtocolContext(ctx, *m.protocolContext)
+}
+
 func (m *Message) Finish(err error) error {
 	if m.BodyReader != nil {
 		_ = m.BodyReader.Close()
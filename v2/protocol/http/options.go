@@ -7,6 +7,8 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
 )
 
 // Option is the function signature required to be considered an http.Option.
@@ -254,3 +256,17 @@ func WithIsRetriableFunc(isRetriable IsRetriable) Option {
 		return nil
 	}
 }
+
+// WithValidationLevel sets the validation level applied to incoming requests
+// before handing them to the receiver. With a level stricter than
+// event.ValidationLevelDefault, requests carrying non-compliant events are
+// rejected with 400 Bad Request and never reach the receiver.
+func WithValidationLevel(level event.ValidationLevel) Option {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http validation level option can not set nil protocol")
+		}
+		p.validationLevel = level
+		return nil
+	}
+}
@@ -12,8 +12,10 @@ import (
 	"time"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
 	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 	"go.opencensus.io/plugin/ochttp"
 	"go.opencensus.io/plugin/ochttp/propagation/tracecontext"
@@ -25,7 +27,7 @@ const (
 )
 
 type msgErr struct {
-	msg    *Message
+	msg    binding.Message
 	respFn protocol.ResponseFn
 	err    error
 }
@@ -84,6 +86,7 @@ type Protocol struct {
 	middleware        []Middleware
 
 	isRetriableFunc IsRetriable
+	validationLevel event.ValidationLevel
 }
 
 func New(opts ...Option) (*Protocol, error) {
@@ -318,6 +321,28 @@ func (p *Protocol) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		return nil
 	}
 
+	var in binding.Message = m
+	if p.validationLevel > event.ValidationLevelDefault {
+		e, err := p.validateRequest(req.Context(), m)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, binding.ErrUnknownEncoding) {
+				status = http.StatusUnsupportedMediaType
+			}
+			rw.Header().Set("content-type", "text/plain")
+			rw.WriteHeader(status)
+			_, _ = rw.Write([]byte(err.Error()))
+			return
+		}
+		// The body has been consumed, forward the parsed event instead.
+		in = &validatedMessage{
+			Message: binding.WithFinish(binding.ToMessage(e), func(err error) {
+				finishErr = err
+			}),
+			request: m,
+		}
+	}
+
 	wg := sync.WaitGroup{}
 	wg.Add(1)
 	var fn protocol.ResponseFn = func(ctx context.Context, respMsg binding.Message, res protocol.Result, transformers ...binding.Transformer) error {
@@ -366,11 +391,57 @@ func (p *Protocol) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		return nil
 	}
 
-	p.incoming <- msgErr{msg: m, respFn: fn} // Send to Request
+	p.incoming <- msgErr{msg: in, respFn: fn} // Send to Request
 	// Block until ResponseFn is invoked
 	wg.Wait()
 }
 
+// validateRequest converts m to an event and validates it with the configured
+// validation level. m is finished before returning.
+func (p *Protocol) validateRequest(ctx context.Context, m *Message) (*event.Event, error) {
+	e, err := binding.ToEvent(ctx, m)
+	_ = m.Finish(nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.ValidateWithLevel(p.validationLevel); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// validatedMessage forwards the event decoded by the strict validation, as the
+// body of the request has been consumed, exposing the protocol context and
+// the trace context of the request.
+type validatedMessage struct {
+	binding.Message
+	request *Message
+}
+
+var _ binding.MessageWrapper = (*validatedMessage)(nil)
+var _ binding.ProtocolContextMessage = (*validatedMessage)(nil)
+var _ extensions.TraceContextReader = (*validatedMessage)(nil)
+
+func (m *validatedMessage) GetAttribute(k spec.Kind) (spec.Attribute, interface{}) {
+	return m.Message.(binding.MessageMetadataReader).GetAttribute(k)
+}
+
+func (m *validatedMessage) GetExtension(name string) interface{} {
+	return m.Message.(binding.MessageMetadataReader).GetExtension(name)
+}
+
+func (m *validatedMessage) GetWrappedMessage() binding.Message {
+	return m.Message
+}
+
+func (m *validatedMessage) WithProtocolContext(ctx context.Context) context.Context {
+	return m.request.WithProtocolContext(ctx)
+}
+
+func (m *validatedMessage) ReadTraceContext() (extensions.DistributedTracingExtension, bool) {
+	return m.request.ReadTraceContext()
+}
+
 func defaultIsRetriableFunc(sc int) bool {
 	_, ok := defaultRetriableErrors[sc]
 	return ok
@@ -7,8 +7,6 @@ import (
 	"net/url"
 	"time"
 
-	"go.uber.org/zap"
-
 	"github.com/cloudevents/sdk-go/v2/binding"
 	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/cloudevents/sdk-go/v2/protocol"
@@ -76,9 +74,9 @@ func (p *Protocol) doWithRetry(ctx context.Context, params *cecontext.RetryParam
 					goto DoBackoff
 				} else {
 					// Permanent error
-					cecontext.LoggerFrom(ctx).Debugw("status code not retryable, will not try again",
-						zap.Error(httpResult),
-						zap.Int("statusCode", sc))
+					cecontext.StructuredLoggerFrom(ctx).Debugw("status code not retryable, will not try again",
+						"error", httpResult,
+						"statusCode", sc)
 					return msg, NewRetriesResult(result, retry, then, results)
 				}
 			}
@@ -90,7 +88,7 @@ func (p *Protocol) doWithRetry(ctx context.Context, params *cecontext.RetryParam
 		// total tries = retry + 1
 		if err := params.Backoff(ctx, retry+1); err != nil {
 			// do not try again.
-			cecontext.LoggerFrom(ctx).Debugw("backoff error, will not try again", zap.Error(err))
+			cecontext.StructuredLoggerFrom(ctx).Debugw("backoff error, will not try again", "error", err)
 			return msg, NewRetriesResult(result, retry, then, results)
 		}
 
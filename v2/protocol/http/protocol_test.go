@@ -5,10 +5,13 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -237,6 +240,68 @@ func TestServeHTTP_Receive(t *testing.T) {
 	}
 }
 
+func TestServeHTTP_StrictValidation(t *testing.T) {
+	p, err := New(WithValidationLevel(event.ValidationLevelStrict))
+	require.NoError(t, err)
+
+	newRequest := func(dataSchema string) *http.Request {
+		req := httptest.NewRequest("POST", "http://unittest", strings.NewReader(`{"hello":"world"}`))
+		req.Header.Set("ce-specversion", "1.0")
+		req.Header.Set("ce-id", "123")
+		req.Header.Set("ce-type", "unit.test")
+		req.Header.Set("ce-source", "/unit/test")
+		req.Header.Set("ce-dataschema", dataSchema)
+		req.Header.Set("content-type", "application/json")
+		return req
+	}
+
+	t.Run("non-compliant event is rejected", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, newRequest("/schema.json"))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), "dataschema: if present, MUST be an absolute URI")
+	})
+
+	t.Run("unsupported content type is rejected", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "http://unittest", strings.NewReader("hello"))
+		req.Header.Set("content-type", "text/plain")
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("compliant event is received", func(t *testing.T) {
+		const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+		rec := httptest.NewRecorder()
+		done := make(chan struct{})
+		req := newRequest("http://example.com/schema.json")
+		req.Header.Set(extensions.TraceParentHeader, traceParent)
+		go func() {
+			p.ServeHTTP(rec, req)
+			close(done)
+		}()
+
+		m, err := p.Receive(context.Background())
+		require.NoError(t, err)
+		e, err := binding.ToEvent(context.Background(), m)
+		require.NoError(t, err)
+		require.Equal(t, "123", e.ID())
+		require.Equal(t, `{"hello":"world"}`, string(e.Data()))
+
+		// The protocol context and the trace context of the request are kept
+		pctx := ProtocolContextFrom(binding.WithProtocolContext(context.Background(), m))
+		require.Equal(t, "192.0.2.1:1234", pctx.RemoteAddr)
+		require.Equal(t, "123", pctx.Header.Get("ce-id"))
+		tc, ok := extensions.ReadNativeTraceContext(m)
+		require.True(t, ok)
+		require.Equal(t, traceParent, tc.TraceParent)
+		require.NoError(t, m.Finish(nil))
+
+		<-done
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
 func ReceiveTest(t *testing.T, p *Protocol, ctx context.Context, rec *httptest.ResponseRecorder, want binding.Message, wantErr string) {
 	got, err := p.Receive(ctx)
 	if wantErr != "" {
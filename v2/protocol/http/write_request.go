@@ -11,11 +11,13 @@ import (
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/format"
 	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/types"
 )
 
 // WriteRequest fills the provided httpRequest with the message m.
 // Using context you can tweak the encoding processing (more details on binding.Write documentation).
+// The trace context of ctx, if any, is written in the W3C traceparent and tracestate headers.
 func WriteRequest(ctx context.Context, m binding.Message, httpRequest *http.Request, transformers ...binding.Transformer) error {
 	structuredWriter := (*httpRequestWriter)(httpRequest)
 	binaryWriter := (*httpRequestWriter)(httpRequest)
@@ -27,6 +29,12 @@ func WriteRequest(ctx context.Context, m binding.Message, httpRequest *http.Requ
 		binaryWriter,
 		transformers...,
 	)
+	if tc, ok := extensions.TraceContextFromContext(ctx); ok && err == nil {
+		httpRequest.Header.Set(extensions.TraceParentHeader, tc.TraceParent)
+		if tc.TraceState != "" {
+			httpRequest.Header.Set(extensions.TraceStateHeader, tc.TraceState)
+		}
+	}
 	return err
 }
 